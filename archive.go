@@ -0,0 +1,174 @@
+package shapefile
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/everystreet/go-shapefile/dbf"
+	"github.com/everystreet/go-shapefile/dbf/memo"
+	"golang.org/x/net/html/charset"
+)
+
+// NewArchiveScanner creates a scanner for a shapefile bundled in any of the supported archive formats,
+// which is chosen according to the extension of filename. Files named *.zip, *.shz and *.shp.zip
+// are read using ZipScanner, and files named *.tar, *.tar.gz and *.tgz are read using TarScanner.
+func NewArchiveScanner(r io.ReaderAt, size int64, filename string, opts ...Option) (Scannable, error) {
+	switch lower := strings.ToLower(filename); {
+	case strings.HasSuffix(lower, ".zip"), strings.HasSuffix(lower, ".shz"):
+		return NewZipScanner(r, size, filename, opts...)
+	case strings.HasSuffix(lower, ".tar"), strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return NewTarScanner(io.NewSectionReader(r, 0, size), filename, opts...)
+	default:
+		return nil, fmt.Errorf("unsupported archive format '%s'", filename)
+	}
+}
+
+// archive provides access to the members of a container holding one or more shapefile layers.
+type archive interface {
+	names() []string
+	open(name string) (io.ReadCloser, error)
+}
+
+// openLayer opens the shp and dbf files of the layer and creates a Scanner for them.
+// If the layer includes a .cpg file, the character decoder it specifies is applied,
+// and if it includes a .dbt or .fpt memo file, memos are read from it as they're needed.
+// The returned closer closes all files opened by this function.
+func openLayer(a archive, layer Layer, opts []Option) (*Scanner, io.Closer, error) {
+	shpName, ok := layer.File(".shp")
+	if !ok {
+		return nil, nil, fmt.Errorf("missing .shp file")
+	}
+
+	dbfName, ok := layer.File(".dbf")
+	if !ok {
+		return nil, nil, fmt.Errorf("missing .dbf file")
+	}
+
+	var files closers
+	shpR, err := a.open(shpName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open %s: %w", shpName, err)
+	}
+	files = append(files, shpR)
+
+	dbfR, err := a.open(dbfName)
+	if err != nil {
+		files.Close()
+		return nil, nil, fmt.Errorf("failed to open %s: %w", dbfName, err)
+	}
+	files = append(files, dbfR)
+
+	scannerOpts := make([]Option, len(opts))
+	copy(scannerOpts, opts)
+
+	if cpgName, ok := layer.File(".cpg"); ok {
+		enc, err := readCpg(a, cpgName)
+		if err != nil {
+			files.Close()
+			return nil, nil, err
+		}
+		scannerOpts = append(scannerOpts, characterEncoding(enc))
+	}
+
+	for _, m := range []struct {
+		ext  string
+		open func(io.ReaderAt, int64) (*memo.File, error)
+	}{
+		{".dbt", memo.NewDBT},
+		{".fpt", memo.NewFPT},
+	} {
+		if name, ok := layer.File(m.ext); ok {
+			f, r, err := openMemo(a, name, m.open)
+			if err != nil {
+				files.Close()
+				return nil, nil, err
+			}
+			files = append(files, r)
+			scannerOpts = append(scannerOpts, MemoFile(f))
+		}
+	}
+
+	return NewScanner(shpR, dbfR, scannerOpts...), files, nil
+}
+
+// openMemo opens a memo file, which is read at random if the archive member supports it.
+// Otherwise, as is the case for compressed zip members, the whole file is read into memory.
+// The returned closer closes the archive member.
+func openMemo(
+	a archive,
+	name string,
+	open func(io.ReaderAt, int64) (*memo.File, error),
+) (*memo.File, io.Closer, error) {
+	r, err := a.open(name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open memo file: %w", err)
+	}
+
+	in, size, err := memoReaderAt(r)
+	if err != nil {
+		r.Close()
+		return nil, nil, fmt.Errorf("failed to read memo file: %w", err)
+	}
+
+	f, err := open(in, size)
+	if err != nil {
+		r.Close()
+		return nil, nil, fmt.Errorf("failed to parse memo file: %w", err)
+	}
+	return f, r, nil
+}
+
+func memoReaderAt(r io.Reader) (io.ReaderAt, int64, error) {
+	if ra, ok := r.(io.ReaderAt); ok {
+		if s, ok := r.(io.Seeker); ok {
+			size, err := s.Seek(0, io.SeekEnd)
+			return ra, size, err
+		}
+	}
+
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, 0, err
+	}
+	return bytes.NewReader(buf), int64(len(buf)), nil
+}
+
+func readCpg(a archive, name string) (dbf.Encoding, error) {
+	r, err := a.open(name)
+	if err != nil {
+		return dbf.Encoding{}, fmt.Errorf("failed to open cpg file: %w", err)
+	}
+	defer r.Close()
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		str := strings.TrimSpace(scanner.Text())
+		if len(str) == 0 {
+			continue
+		}
+
+		enc, name := charset.Lookup(str)
+		if enc == nil {
+			return dbf.Encoding{}, fmt.Errorf("unknown charset '%s'", str)
+		}
+		return dbf.Encoding{Name: name, Encoding: enc}, nil
+	}
+	return dbf.Encoding{}, fmt.Errorf("missing charset")
+}
+
+// closers closes each of a set of files, returning the first error.
+type closers []io.Closer
+
+func (c closers) Close() error {
+	var err error
+	for _, f := range c {
+		if cerr := f.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
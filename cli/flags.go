@@ -5,12 +5,31 @@ import (
 	"io"
 
 	"github.com/everystreet/go-shapefile"
+	"github.com/everystreet/go-shapefile/dbf/expr"
+	"gopkg.in/alecthomas/kingpin.v2"
 )
 
 type Flags struct {
-	Zip string `kong:"optional,name=zip,short=z,type=existingfile,help='Path to zipped (.zip) shapefile. Not to be used in conjunction with --shp or --dbf.'"`
-	Shp string `kong:"optional,name=shp,type=existingfile,help='Path to shape file (.shp). Must be used in conjunction with --dbf.'"`
+	Zip string `kong:"optional,name=zip,short=z,type=existingfile,help='Path to zipped (.zip, .shz) or tarred (.tar, .tar.gz) shapefile. Not to be used in conjunction with --shp or --dbf.'"`
+	Shp string `kong:"optional,name=shp,type=existingfile,help='Path to shape file (.shp). Sibling files are found automatically if --dbf is not specified.'"`
 	Dbf string `kong:"optional,name=dbf,type=existingfile,help='Path to attribute file (.dbf). Must be used in conjunction with --shp.'"`
+
+	Where string `kong:"optional,name=where,short=w,help='Only records whose attributes satisfy the expression, such as POP_EST > 1000000.'"`
+}
+
+// Register adds the --zip, --shp, --dbf and --where flags to the command.
+func (f *Flags) Register(cmd *kingpin.CmdClause) {
+	cmd.Flag("zip",
+		"Zipped (.zip, .shz) or tarred (.tar, .tar.gz) shape file. Cannot be used with --shp or --dbf.").
+		Short('z').StringVar(&f.Zip)
+	cmd.Flag("shp",
+		"Shape file (.shp) path. Sibling files are found automatically if --dbf is not specified.").
+		Short('s').StringVar(&f.Shp)
+	cmd.Flag("dbf",
+		"Attribute file (.dbf) path. Must be used in combination with --shp.").Short('d').StringVar(&f.Dbf)
+	cmd.Flag("where",
+		"Only records whose attributes satisfy the expression, such as \"POP_EST > 1000000 AND CONTINENT = 'Asia'\".").
+		Short('w').StringVar(&f.Where)
 }
 
 func (f Flags) OpenAllFields() (shapefile.Scannable, io.Closer, error) {
@@ -22,11 +41,28 @@ func (f Flags) OpenFilteredFields(fields []string) (shapefile.Scannable, io.Clos
 }
 
 func (f Flags) open(fields []string) (shapefile.Scannable, io.Closer, error) {
+	s, closer, err := f.openSource(fields)
+	if err != nil || f.Where == "" {
+		return s, closer, err
+	}
+
+	e, err := expr.Parse(f.Where)
+	if err != nil {
+		return nil, closer, err
+	}
+
+	s.AddOptions(shapefile.Where(e))
+	return s, closer, nil
+}
+
+func (f Flags) openSource(fields []string) (shapefile.Scannable, io.Closer, error) {
 	if f.Zip != "" {
 		if f.Shp != "" || f.Dbf != "" {
 			return nil, nil, fmt.Errorf("--zip cannot be used with --shp or --dbf")
 		}
-		return OpenZip(f.Zip, fields)
+		return OpenArchive(f.Zip, fields)
+	} else if f.Shp != "" && f.Dbf == "" {
+		return OpenPath(f.Shp, fields)
 	} else if f.Shp != "" || f.Dbf != "" {
 		if f.Zip != "" {
 			return nil, nil, fmt.Errorf("--shp and --dbf cannot be used with --zip")
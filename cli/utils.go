@@ -38,6 +38,35 @@ func OpenZip(path string, exclusive []string) (*shapefile.ZipScanner, closer, er
 	return scan, close, err
 }
 
+func OpenArchive(path string, exclusive []string) (shapefile.Scannable, closer, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open archive '%s': %w", path, err)
+	}
+
+	close := func() error {
+		if err := file.Close(); err != nil {
+			return fmt.Errorf("failed to close archive: %w", err)
+		}
+		return nil
+	}
+
+	stat, err := file.Stat()
+	if err != nil {
+		return nil, close, fmt.Errorf("failed to stat archive: %w", err)
+	}
+
+	_, name := filepath.Split(path)
+
+	if len(exclusive) == 0 {
+		scan, err := shapefile.NewArchiveScanner(file, stat.Size(), name)
+		return scan, close, err
+	}
+
+	scan, err := shapefile.NewArchiveScanner(file, stat.Size(), name, shapefile.FilterFields(exclusive...))
+	return scan, close, err
+}
+
 func OpenExtracted(shpPath, dbfPath string, exclusive []string) (*shapefile.Scanner, io.Closer, error) {
 	shp, err := os.Open(shpPath)
 	if err != nil {
@@ -64,6 +93,19 @@ func OpenExtracted(shpPath, dbfPath string, exclusive []string) (*shapefile.Scan
 	return shapefile.NewScanner(shp, dbf, shapefile.FilterFields(exclusive...)), close, err
 }
 
+func OpenPath(shpPath string, exclusive []string) (*shapefile.File, io.Closer, error) {
+	var opts []shapefile.Option
+	if len(exclusive) != 0 {
+		opts = append(opts, shapefile.FilterFields(exclusive...))
+	}
+
+	file, err := shapefile.OpenPath(shpPath, opts...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open shapefile '%s': %w", shpPath, err)
+	}
+	return file, file, nil
+}
+
 type closer func() error
 
 func (c closer) Close() error {
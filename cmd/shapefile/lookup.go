@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/everystreet/go-shapefile/cli"
+	"github.com/everystreet/go-shapefile/lookup"
+)
+
+type lookupOptions struct {
+	flags  cli.Flags
+	fields []string
+	addr   string
+}
+
+func serveLookup(opts lookupOptions) error {
+	s, closer, err := opts.flags.OpenFilteredFields(opts.fields)
+	if closer != nil {
+		defer closer.Close()
+	}
+	if err != nil {
+		return err
+	}
+
+	l, err := lookup.New(s)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Listening on http://%s/?lat=LAT&lng=LNG\n", opts.addr)
+	return http.ListenAndServe(opts.addr, l)
+}
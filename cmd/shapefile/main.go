@@ -3,53 +3,95 @@ package main
 import (
 	"fmt"
 	"os"
-	"path/filepath"
+	"strconv"
 	"strings"
 	"text/tabwriter"
 
 	"github.com/everystreet/go-shapefile"
+	"github.com/everystreet/go-shapefile/cli"
 	"github.com/everystreet/go-shapefile/dbf"
 	"github.com/everystreet/go-shapefile/dbf/dbase5"
+	"github.com/everystreet/go-shapefile/dbf/dbase7"
+	"github.com/everystreet/go-shapefile/dbf/vfp"
 	"github.com/olekukonko/tablewriter"
 	"gopkg.in/alecthomas/kingpin.v2"
 )
 
 func main() {
 	readCommand := kingpin.Command("read", "Display shapefile data.")
-	readZipPath := readCommand.Flag("zip",
-		"Zipped shape file (.zip). Cannot be used with --shp or --dbf.").Short('z').String()
-	readShpPath := readCommand.Flag("shp",
-		"Shape file (.shp) path. Must be used in combination with --dbf.").Short('s').String()
-	readDbfPath := readCommand.Flag("dbf",
-		"Attribute file (.dbf) path. Must be used in combination with --shp.").Short('d').String()
+	var read cli.Flags
+	read.Register(readCommand)
 	readFields := readCommand.Flag("fields", "Only the specified field names.").Short('f').Strings()
 	readListFields := readCommand.Flag("list-fields", "List fields only - no data.").Bool()
 	readAttributes := readCommand.Flag("attributes", "Attributes only.").Bool()
 	pretty := readCommand.Flag("pretty", "Enable pretty-printing.").Short('p').Bool()
 
+	tilesCommand := kingpin.Command("tiles", "Generate vector tiles.")
+	var tiles tilesOptions
+	tiles.flags.Register(tilesCommand)
+	tilesCommand.Flag("fields", "Only include the specified field names as feature attributes.").
+		Short('f').StringsVar(&tiles.fields)
+	tilesCommand.Flag("out",
+		"Output directory for z/x/y.pbf tiles, or file path ending in .mbtiles.").Short('o').Required().StringVar(&tiles.out)
+	tilesCommand.Flag("layer", "Layer name. Defaults to the name of the input file.").StringVar(&tiles.layer)
+	tilesCommand.Flag("min-zoom", "Minimum zoom level.").Default("0").Uint32Var(&tiles.minZoom)
+	tilesCommand.Flag("max-zoom", "Maximum zoom level.").Default("14").Uint32Var(&tiles.maxZoom)
+	tilesCommand.Flag("tolerance",
+		"Simplification tolerance in tile units, applied at each zoom level. 0 disables simplification.").
+		Default("1").Float64Var(&tiles.tolerance)
+
+	renderCommand := kingpin.Command("render", "Draw shapes as an SVG document.")
+	var rend renderOptions
+	rend.flags.Register(renderCommand)
+	renderCommand.Flag("out", "Output SVG file. Defaults to stdout.").Short('o').StringVar(&rend.out)
+	renderCommand.Flag("width", "Width in pixels.").Default("1024").IntVar(&rend.width)
+	renderCommand.Flag("fill", "Default fill colour.").Default("lightgrey").StringVar(&rend.fill)
+	renderCommand.Flag("stroke", "Stroke colour.").Default("black").StringVar(&rend.stroke)
+	renderCommand.Flag("stroke-width", "Stroke width in pixels.").Default("1").Float64Var(&rend.strokeWidth)
+	renderCommand.Flag("point-radius", "Radius of points in pixels.").Default("3").IntVar(&rend.pointRadius)
+	renderCommand.Flag("category-field", "Field used to choose fill colours by category.").
+		StringVar(&rend.categoryField)
+	renderCommand.Flag("category", "Fill colour for a category, as VALUE=COLOUR. May be repeated.").
+		StringMapVar(&rend.categories)
+	renderCommand.Flag("graduated-field", "Numeric field used to choose fill colours by range.").
+		StringVar(&rend.graduatedField)
+	renderCommand.Flag("break", "Inclusive upper bound of a range, in ascending order. May be repeated.").
+		Float64ListVar(&rend.breaks)
+	renderCommand.Flag("color", "Fill colour of a range, with one more than the number of breaks. May be repeated.").
+		StringsVar(&rend.colors)
+	renderCommand.Flag("label", "Field used to label shapes.").StringVar(&rend.label)
+	renderCommand.Flag("font-size", "Label font size in pixels.").Default("10").IntVar(&rend.fontSize)
+
+	lookupCommand := kingpin.Command("lookup", "Serve reverse geocoding queries against a polygon shapefile over HTTP.")
+	var look lookupOptions
+	look.flags.Register(lookupCommand)
+	lookupCommand.Flag("fields", "Only include the specified field names in responses.").
+		Short('f').StringsVar(&look.fields)
+	lookupCommand.Flag("addr", "Address to listen on.").Default("localhost:8080").StringVar(&look.addr)
+
 	var err error
 	switch kingpin.Parse() {
 	case readCommand.FullCommand():
 		switch {
-		case *readZipPath == "" && *readShpPath == "" && *readDbfPath == "":
+		case read.Zip == "" && read.Shp == "" && read.Dbf == "":
 			err = fmt.Errorf("no data source specified")
-		case *readZipPath != "" && (*readShpPath != "" || *readDbfPath != ""):
-			err = fmt.Errorf("--zip cannot be used with --shp or --dbf")
-		case *readZipPath != "":
-			err = dataFromZip(*readZipPath, readFields, *readListFields, *pretty)
-		case *readAttributes && *readShpPath != "":
+		case *readAttributes && read.Shp != "":
 			err = fmt.Errorf("--shp cannot be used with --attributes")
-		case *readAttributes == true && *readDbfPath != "":
-			err = attributesFromDbf(*readDbfPath, readFields, *pretty)
-		case *readListFields == false && (*readShpPath == "" || *readDbfPath == ""):
-			err = fmt.Errorf("--shp and --dbf must be used together")
-		case *readListFields == true && *readDbfPath != "":
-			err = fieldsFromExtracted(*readDbfPath, *pretty)
-		case *readShpPath != "" && *readDbfPath != "":
-			err = dataFromExtracted(*readShpPath, *readDbfPath, readFields, *pretty)
+		case *readAttributes && read.Where != "":
+			err = fmt.Errorf("--where cannot be used with --attributes")
+		case *readAttributes && read.Dbf != "":
+			err = attributesFromDbf(read.Dbf, readFields, *pretty)
+		case *readListFields && read.Zip == "" && read.Shp == "":
+			err = fieldsFromExtracted(read.Dbf, *pretty)
 		default:
-			err = fmt.Errorf("unspecified source")
+			err = readData(read, readFields, *readListFields, *pretty)
 		}
+	case tilesCommand.FullCommand():
+		err = generateTiles(tiles)
+	case renderCommand.FullCommand():
+		err = renderSVG(rend)
+	case lookupCommand.FullCommand():
+		err = serveLookup(look)
 	default:
 		fmt.Fprintf(os.Stderr, "Invalid command\n")
 		os.Exit(1)
@@ -60,29 +102,13 @@ func main() {
 	}
 }
 
-func dataFromZip(path string, fields *[]string, meta, pretty bool) error {
-	f, err := os.Open(path)
-	if err != nil {
-		return fmt.Errorf("failed to open zip file '%s': %w", path, err)
+func readData(flags cli.Flags, fields *[]string, meta, pretty bool) error {
+	s, closer, err := flags.OpenFilteredFields(*fields)
+	if closer != nil {
+		defer closer.Close()
 	}
-	defer f.Close()
-
-	stat, err := f.Stat()
 	if err != nil {
-		return fmt.Errorf("failed to stat zip file '%s': %w", path, err)
-	}
-
-	_, name := filepath.Split(path)
-
-	var s *shapefile.ZipScanner
-	if fields == nil || len(*fields) == 0 {
-		if s, err = shapefile.NewZipScanner(f, stat.Size(), name); err != nil {
-			return err
-		}
-	} else {
-		if s, err = shapefile.NewZipScanner(f, stat.Size(), name, shapefile.FilterFields(*fields...)); err != nil {
-			return err
-		}
+		return err
 	}
 
 	if meta {
@@ -99,28 +125,6 @@ func dataFromZip(path string, fields *[]string, meta, pretty bool) error {
 	return dataTable(s, fields, pretty)
 }
 
-func dataFromExtracted(shpPath, dbfPath string, fields *[]string, pretty bool) error {
-	shpFile, err := os.Open(shpPath)
-	if err != nil {
-		return fmt.Errorf("failed to open shape file '%s': %w", shpPath, err)
-	}
-	defer shpFile.Close()
-
-	dbfFile, err := os.Open(dbfPath)
-	if err != nil {
-		return fmt.Errorf("failed to open attributes file '%s': %w", dbfPath, err)
-	}
-	defer dbfFile.Close()
-
-	var s *shapefile.Scanner
-	if fields == nil || len(*fields) == 0 {
-		s = shapefile.NewScanner(shpFile, dbfFile)
-	} else {
-		s = shapefile.NewScanner(shpFile, dbfFile, shapefile.FilterFields(*fields...))
-	}
-	return dataTable(s, fields, pretty)
-}
-
 func fieldsFromExtracted(dbfPath string, pretty bool) error {
 	dbfFile, err := os.Open(dbfPath)
 	if err != nil {
@@ -134,20 +138,16 @@ func fieldsFromExtracted(dbfPath string, pretty bool) error {
 		return fmt.Errorf("failed to parse dbf header: %w", err)
 	}
 
-	switch h := header.(type) {
-	case *dbase5.Header:
-		fields := make([]shapefile.FieldDesc, len(h.Fields))
-		for i, f := range h.Fields {
-			fields[i] = f
-		}
+	descs := header.FieldDescs()
+	fields := make([]shapefile.FieldDesc, len(descs))
+	for i, f := range descs {
+		fields[i] = f
+	}
 
-		if pretty {
-			return fieldsPrettyTable(fields)
-		}
-		return fieldsTable(fields)
-	default:
-		return fmt.Errorf("unrecognized file type")
+	if pretty {
+		return fieldsPrettyTable(fields)
 	}
+	return fieldsTable(fields)
 }
 
 func attributesFromDbf(dbfPath string, fields *[]string, pretty bool) error {
@@ -210,7 +210,7 @@ func fieldsTable(fields shapefile.FieldDescList) error {
 func fieldsPrettyTable(fields shapefile.FieldDescList) error {
 	table := tablewriter.NewWriter(os.Stdout)
 	table.SetAutoWrapText(false)
-	table.SetHeader([]string{"Name", "Type"})
+	table.SetHeader([]string{"Name", "Type", "Length", "Decimals"})
 
 	for _, field := range fields {
 		f, err := fieldRow(field)
@@ -225,6 +225,15 @@ func fieldsPrettyTable(fields shapefile.FieldDescList) error {
 }
 
 func fieldRow(field shapefile.FieldDesc) ([]string, error) {
+	typ, err := fieldType(field)
+	if err != nil {
+		return nil, err
+	}
+	return []string{field.Name(), typ, strconv.Itoa(int(field.Length())), strconv.Itoa(int(field.Decimals()))}, nil
+}
+
+// fieldType returns a readable name for the type of the field.
+func fieldType(field shapefile.FieldDesc) (string, error) {
 	switch f := field.(type) {
 	case *dbase5.FieldDesc:
 		typ := ""
@@ -245,8 +254,76 @@ func fieldRow(field shapefile.FieldDesc) ([]string, error) {
 			typ = fmt.Sprintf("%c", f.Type)
 		}
 
-		return []string{field.Name(), typ}, nil
+		return typ, nil
+	case *dbase7.FieldDesc:
+		typ := ""
+		switch f.Type {
+		case dbase7.AutoincrementType:
+			typ = "Autoincrement"
+		case dbase7.BinaryType:
+			typ = "Binary"
+		case dbase7.CharacterType:
+			typ = "Character"
+		case dbase7.DateType:
+			typ = "Date"
+		case dbase7.DoubleType:
+			typ = "Double"
+		case dbase7.FloatingPointType:
+			typ = "Float"
+		case dbase7.LogicalType:
+			typ = "Logical"
+		case dbase7.LongType:
+			typ = "Long"
+		case dbase7.MemoType:
+			typ = "Memo"
+		case dbase7.NumericType:
+			typ = "Numeric"
+		case dbase7.OLEType:
+			typ = "OLE"
+		case dbase7.TimestampType:
+			typ = "Timestamp"
+		default:
+			typ = fmt.Sprintf("%c", f.Type)
+		}
+
+		return typ, nil
+	case *vfp.FieldDesc:
+		typ := ""
+		switch f.Type {
+		case vfp.BlobType:
+			typ = "Blob"
+		case vfp.CharacterType:
+			typ = "Character"
+		case vfp.CurrencyType:
+			typ = "Currency"
+		case vfp.DateTimeType:
+			typ = "DateTime"
+		case vfp.DateType:
+			typ = "Date"
+		case vfp.DoubleType:
+			typ = "Double"
+		case vfp.FloatingPointType:
+			typ = "Float"
+		case vfp.GeneralType:
+			typ = "General"
+		case vfp.IntegerType:
+			typ = "Integer"
+		case vfp.LogicalType:
+			typ = "Logical"
+		case vfp.MemoType:
+			typ = "Memo"
+		case vfp.NumericType:
+			typ = "Numeric"
+		case vfp.VarbinaryType:
+			typ = "Varbinary"
+		case vfp.VarcharType:
+			typ = "Varchar"
+		default:
+			typ = fmt.Sprintf("%c", f.Type)
+		}
+
+		return typ, nil
 	default:
-		return nil, fmt.Errorf("unrecognized file type")
+		return "", fmt.Errorf("unrecognized file type")
 	}
 }
@@ -0,0 +1,163 @@
+//go:build mbtiles
+// +build mbtiles
+
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+
+	"github.com/everystreet/go-shapefile"
+	"github.com/everystreet/go-shapefile/dbf/dbase5"
+	"github.com/everystreet/go-shapefile/dbf/dbase7"
+	"github.com/everystreet/go-shapefile/dbf/vfp"
+	"github.com/everystreet/go-shapefile/mvt"
+	"github.com/everystreet/go-shapefile/shp"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// writeMBTiles writes the tiles to an SQLite database, following the MBTiles 1.3 specification.
+func writeMBTiles(
+	opts tilesOptions,
+	pyramid mvt.Pyramid,
+	records []*shapefile.Record,
+	box shp.BoundingBox,
+	fields shapefile.FieldDescList,
+) error {
+	if err := os.Remove(opts.out); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove existing file '%s': %w", opts.out, err)
+	}
+
+	db, err := sql.Open("sqlite3", opts.out)
+	if err != nil {
+		return fmt.Errorf("failed to open '%s': %w", opts.out, err)
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, stmt := range []string{
+		"CREATE TABLE metadata (name TEXT, value TEXT)",
+		"CREATE TABLE tiles (zoom_level INTEGER, tile_column INTEGER, tile_row INTEGER, tile_data BLOB)",
+		"CREATE UNIQUE INDEX tile_index ON tiles (zoom_level, tile_column, tile_row)",
+	} {
+		if _, err := tx.Exec(stmt); err != nil {
+			return fmt.Errorf("failed to create tables: %w", err)
+		}
+	}
+
+	vectorLayer := map[string]interface{}{
+		"id":      pyramid.Layer,
+		"minzoom": pyramid.MinZoom,
+		"maxzoom": pyramid.MaxZoom,
+		"fields":  layerFields(fields, opts.fields),
+	}
+	layers, err := json.Marshal(map[string]interface{}{
+		"vector_layers": []interface{}{vectorLayer},
+	})
+	if err != nil {
+		return err
+	}
+
+	box = shp.BoundingBox{
+		MinX: math.Max(box.MinX, -180),
+		MinY: math.Max(box.MinY, -85.0511),
+		MaxX: math.Min(box.MaxX, 180),
+		MaxY: math.Min(box.MaxY, 85.0511),
+	}
+
+	metadata := [][2]string{
+		{"name", pyramid.Layer},
+		{"format", "pbf"},
+		{"minzoom", strconv.Itoa(int(pyramid.MinZoom))},
+		{"maxzoom", strconv.Itoa(int(pyramid.MaxZoom))},
+		{"bounds", fmt.Sprintf("%g,%g,%g,%g", box.MinX, box.MinY, box.MaxX, box.MaxY)},
+		{"center", fmt.Sprintf("%g,%g,%d", (box.MinX+box.MaxX)/2, (box.MinY+box.MaxY)/2, pyramid.MinZoom)},
+		{"json", string(layers)},
+	}
+	for _, m := range metadata {
+		if _, err := tx.Exec("INSERT INTO metadata (name, value) VALUES (?, ?)", m[0], m[1]); err != nil {
+			return fmt.Errorf("failed to write metadata: %w", err)
+		}
+	}
+
+	if err := pyramid.Generate(records, func(tile mvt.Tile, data []byte) error {
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		if _, err := w.Write(data); err != nil {
+			return err
+		} else if err := w.Close(); err != nil {
+			return err
+		}
+
+		// MBTiles uses the TMS scheme, where rows are numbered from the bottom
+		row := (uint32(1) << tile.Z) - 1 - tile.Y
+		if _, err := tx.Exec(
+			"INSERT INTO tiles (zoom_level, tile_column, tile_row, tile_data) VALUES (?, ?, ?, ?)",
+			tile.Z, tile.X, row, buf.Bytes(),
+		); err != nil {
+			return fmt.Errorf("failed to write tile %s: %w", tile, err)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// layerFields describes the type of each field, as required by the vector_layers metadata.
+// If names is not empty, only those fields are included.
+func layerFields(fields shapefile.FieldDescList, names []string) map[string]string {
+	include := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		include[name] = struct{}{}
+	}
+
+	out := make(map[string]string, len(fields))
+	for _, field := range fields {
+		if _, ok := include[field.Name()]; len(include) > 0 && !ok {
+			continue
+		}
+
+		out[field.Name()] = layerFieldType(field)
+	}
+	return out
+}
+
+// layerFieldType returns the vector_layers type of the field, which is Number, Boolean or String.
+func layerFieldType(field shapefile.FieldDesc) string {
+	switch f := field.(type) {
+	case *dbase5.FieldDesc:
+		switch f.Type {
+		case dbase5.FloatingPointType, dbase5.NumericType:
+			return "Number"
+		case dbase5.LogicalType:
+			return "Boolean"
+		}
+	case *dbase7.FieldDesc:
+		switch f.Type {
+		case dbase7.AutoincrementType, dbase7.DoubleType, dbase7.FloatingPointType, dbase7.LongType, dbase7.NumericType:
+			return "Number"
+		case dbase7.LogicalType:
+			return "Boolean"
+		}
+	case *vfp.FieldDesc:
+		switch f.Type {
+		case vfp.CurrencyType, vfp.DoubleType, vfp.FloatingPointType, vfp.IntegerType, vfp.NumericType:
+			return "Number"
+		case vfp.LogicalType:
+			return "Boolean"
+		}
+	}
+	return "String"
+}
@@ -0,0 +1,17 @@
+//go:build !mbtiles
+// +build !mbtiles
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/everystreet/go-shapefile"
+	"github.com/everystreet/go-shapefile/mvt"
+	"github.com/everystreet/go-shapefile/shp"
+)
+
+// writeMBTiles is unavailable unless the mbtiles build tag is set, as the SQLite driver requires cgo.
+func writeMBTiles(tilesOptions, mvt.Pyramid, []*shapefile.Record, shp.BoundingBox, shapefile.FieldDescList) error {
+	return fmt.Errorf("MBTiles output requires the command to be built with -tags mbtiles")
+}
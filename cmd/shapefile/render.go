@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/everystreet/go-shapefile/cli"
+	"github.com/everystreet/go-shapefile/render"
+)
+
+type renderOptions struct {
+	flags       cli.Flags
+	out         string
+	width       int
+	fill        string
+	stroke      string
+	strokeWidth float64
+	pointRadius int
+
+	categoryField  string
+	categories     map[string]string
+	graduatedField string
+	breaks         []float64
+	colors         []string
+
+	label    string
+	fontSize int
+}
+
+func renderSVG(opts renderOptions) error {
+	if opts.categoryField != "" && opts.graduatedField != "" {
+		return fmt.Errorf("--category-field cannot be used with --graduated-field")
+	}
+
+	s, closer, err := opts.flags.OpenAllFields()
+	if closer != nil {
+		defer closer.Close()
+	}
+	if err != nil {
+		return err
+	}
+
+	renderOpts := []render.Option{
+		render.Width(opts.width),
+		render.Fill(opts.fill),
+		render.Stroke(opts.stroke, opts.strokeWidth),
+		render.PointRadius(opts.pointRadius),
+	}
+
+	switch {
+	case opts.categoryField != "":
+		renderOpts = append(renderOpts, render.FillRule(render.Categorized{
+			Field:  opts.categoryField,
+			Colors: opts.categories,
+		}))
+	case opts.graduatedField != "":
+		if len(opts.colors) != len(opts.breaks)+1 {
+			return fmt.Errorf("expecting %d colors for %d breaks but have %d",
+				len(opts.breaks)+1, len(opts.breaks), len(opts.colors))
+		}
+
+		renderOpts = append(renderOpts, render.FillRule(render.Graduated{
+			Field:  opts.graduatedField,
+			Breaks: opts.breaks,
+			Colors: opts.colors,
+		}))
+	}
+
+	if opts.label != "" {
+		renderOpts = append(renderOpts, render.Labels(opts.label, opts.fontSize))
+	}
+
+	var w io.Writer = os.Stdout
+	if opts.out != "" {
+		f, err := os.Create(opts.out)
+		if err != nil {
+			return fmt.Errorf("failed to create '%s': %w", opts.out, err)
+		}
+		defer f.Close()
+		w = f
+	}
+	return render.SVG(w, s, renderOpts...)
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/everystreet/go-shapefile"
+	"github.com/everystreet/go-shapefile/cli"
+	"github.com/everystreet/go-shapefile/mvt"
+	"github.com/everystreet/go-shapefile/shp"
+)
+
+type tilesOptions struct {
+	flags     cli.Flags
+	fields    []string
+	out       string
+	layer     string
+	minZoom   uint32
+	maxZoom   uint32
+	tolerance float64
+}
+
+func generateTiles(opts tilesOptions) error {
+	s, closer, err := opts.flags.OpenFilteredFields(opts.fields)
+	if closer != nil {
+		defer closer.Close()
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := s.Scan(); err != nil {
+		return err
+	}
+
+	var records []*shapefile.Record
+	var shapes shp.Shapes
+	for {
+		rec := s.Record()
+		if rec == nil {
+			break
+		}
+		records = append(records, rec)
+		shapes = append(shapes, rec.Shape)
+	}
+
+	if err := s.Err(); err != nil {
+		return fmt.Errorf("failed to read shapefile: %w", err)
+	}
+
+	info, err := s.Info()
+	if err != nil {
+		return fmt.Errorf("failed to read shapefile: %w", err)
+	}
+
+	if opts.layer == "" {
+		opts.layer = layerName(opts.flags)
+	}
+
+	pyramid := mvt.Pyramid{
+		Layer:     opts.layer,
+		MinZoom:   opts.minZoom,
+		MaxZoom:   opts.maxZoom,
+		Tolerance: opts.tolerance,
+	}
+
+	if strings.HasSuffix(strings.ToLower(opts.out), ".mbtiles") {
+		return writeMBTiles(opts, pyramid, records, shapes.BoundingBox(), info.Fields)
+	}
+	return pyramid.Generate(records, func(tile mvt.Tile, data []byte) error {
+		dir := filepath.Join(opts.out, strconv.Itoa(int(tile.Z)), strconv.Itoa(int(tile.X)))
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create tile directory: %w", err)
+		}
+		return ioutil.WriteFile(filepath.Join(dir, strconv.Itoa(int(tile.Y))+".pbf"), data, 0644)
+	})
+}
+
+// layerName returns the base name of the input file, without its extensions.
+func layerName(flags cli.Flags) string {
+	name := flags.Shp
+	if flags.Zip != "" {
+		name = flags.Zip
+	}
+
+	name = filepath.Base(name)
+	name = strings.TrimSuffix(name, filepath.Ext(name))
+
+	// Archives are often named after the shapefile they contain, such as name.shp.zip, or end in .tar.gz
+	for _, ext := range []string{".tar", ".shp"} {
+		if strings.EqualFold(filepath.Ext(name), ext) {
+			name = name[:len(name)-len(ext)]
+		}
+	}
+	return name
+}
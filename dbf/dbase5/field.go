@@ -22,8 +22,43 @@ const (
 type FieldDesc struct {
 	Type FieldType
 
-	name string
-	len  uint8
+	name     string
+	len      uint8
+	decimals uint8
+}
+
+// NewFieldDesc creates a field descriptor.
+// The name must be no longer than 10 bytes, and decimals only applies to numeric and floating point fields.
+// Logical fields must be 1 byte long.
+func NewFieldDesc(name string, typ FieldType, length, decimals uint8) (*FieldDesc, error) {
+	if name == "" || len(name) > 10 {
+		return nil, fmt.Errorf("field name '%s' must be between 1 and 10 bytes", name)
+	} else if length == 0 {
+		return nil, fmt.Errorf("field '%s' must have a non-zero length", name)
+	}
+
+	switch typ {
+	case NumericType, FloatingPointType:
+		if decimals > 0 && decimals >= length-1 {
+			return nil, fmt.Errorf("field '%s' is too short for %d decimals", name, decimals)
+		}
+	case LogicalType:
+		if length != 1 {
+			return nil, fmt.Errorf("logical field '%s' must have a length of 1", name)
+		}
+		decimals = 0
+	case CharacterType, DateType, MemoType:
+		decimals = 0
+	default:
+		return nil, fmt.Errorf("unsupported field type '%c'", typ)
+	}
+
+	return &FieldDesc{
+		Type:     typ,
+		name:     name,
+		len:      length,
+		decimals: decimals,
+	}, nil
 }
 
 // DecodeFieldDesc parses a single field descriptor.
@@ -34,13 +69,34 @@ func DecodeFieldDesc(buf []byte) (*FieldDesc, error) {
 
 	name := bytes.Trim(buf[0:11], "\x00")
 	return &FieldDesc{
-		Type: FieldType(buf[11]),
-		name: string(name),
-		len:  buf[16],
+		Type:     FieldType(buf[11]),
+		name:     string(name),
+		len:      buf[16],
+		decimals: buf[17],
 	}, nil
 }
 
+// Encode encodes the field descriptor.
+func (f FieldDesc) Encode() []byte {
+	buf := make([]byte, 32)
+	copy(buf[0:11], f.name)
+	buf[11] = byte(f.Type)
+	buf[16] = f.len
+	buf[17] = f.decimals
+	return buf
+}
+
 // Name of the field.
 func (f FieldDesc) Name() string {
 	return f.name
 }
+
+// Length returns the size of the field in bytes.
+func (f FieldDesc) Length() uint8 {
+	return f.len
+}
+
+// Decimals returns the number of decimal places of a numeric field.
+func (f FieldDesc) Decimals() uint8 {
+	return f.decimals
+}
@@ -4,14 +4,20 @@ import (
 	"encoding/binary"
 	"fmt"
 	"io"
+	"time"
+
+	"github.com/everystreet/go-shapefile/dbf/field"
 )
 
 // Header represents a dBase 5 file header.
 type Header struct {
 	Fields []*FieldDesc
 
-	recLen  uint16
-	numRecs uint32
+	lastUpdate time.Time
+	ldid       uint8
+	headerLen  uint16
+	recLen     uint16
+	numRecs    uint32
 }
 
 // DecodeHeader parses a dBase 5 file header.
@@ -23,13 +29,20 @@ func DecodeHeader(r io.Reader) (*Header, error) {
 	}
 
 	out := &Header{
-		recLen:  binary.LittleEndian.Uint16(buf[9:11]),
-		numRecs: binary.LittleEndian.Uint32(buf[3:7]),
+		lastUpdate: field.DecodeHeaderDate(buf[0:3]),
+		ldid:       buf[28],
+		headerLen:  binary.LittleEndian.Uint16(buf[7:9]),
+		recLen:     binary.LittleEndian.Uint16(buf[9:11]),
+		numRecs:    binary.LittleEndian.Uint32(buf[3:7]),
 	}
 
 	// Read remainder of header
-	headerLen := binary.LittleEndian.Uint16(buf[7:9])
-	buf = make([]byte, int(headerLen)-len(buf)-1)
+	headerLen := int(out.headerLen)
+	if headerLen <= len(buf)+1 {
+		return nil, fmt.Errorf("invalid header size %d bytes", headerLen)
+	}
+
+	buf = make([]byte, headerLen-len(buf)-1)
 	if n, err := io.ReadFull(r, buf); err != nil {
 		return nil, fmt.Errorf("read %d bytes but expecting %d: %w", n, len(buf), err)
 	}
@@ -55,6 +68,58 @@ func DecodeHeader(r io.Reader) (*Header, error) {
 	return out, nil
 }
 
+// NewHeader creates a header for a file containing the specified fields and number of records.
+func NewHeader(fields []*FieldDesc, numRecs uint32) *Header {
+	recLen := 1 // deletion flag
+	for _, f := range fields {
+		recLen += int(f.len)
+	}
+
+	return &Header{
+		Fields:    fields,
+		headerLen: uint16(32 + len(fields)*32 + 1),
+		recLen:    uint16(recLen),
+		numRecs:   numRecs,
+	}
+}
+
+// Encode encodes the header, including the version number and field descriptor terminator.
+// The last update date is set to the current date.
+func (h Header) Encode() []byte {
+	headerLen := 32 + len(h.Fields)*32 + 1
+	buf := make([]byte, 32, headerLen)
+
+	now := time.Now()
+	buf[0] = 0x03 // dBase level 5, without memo
+	buf[1] = byte(now.Year() - 1900)
+	buf[2] = byte(now.Month())
+	buf[3] = byte(now.Day())
+	binary.LittleEndian.PutUint32(buf[4:8], h.numRecs)
+	binary.LittleEndian.PutUint16(buf[8:10], uint16(headerLen))
+	binary.LittleEndian.PutUint16(buf[10:12], h.recLen)
+
+	for _, f := range h.Fields {
+		buf = append(buf, f.Encode()...)
+	}
+	return append(buf, 0x0D)
+}
+
+// LastUpdate returns the date that the file was last updated.
+func (h Header) LastUpdate() time.Time {
+	return h.lastUpdate
+}
+
+// LanguageDriverID returns the language driver ID, which identifies the code page of character fields.
+// Zero means that it isn't set.
+func (h Header) LanguageDriverID() uint8 {
+	return h.ldid
+}
+
+// HeaderLen returns the size in bytes of the header, including the field descriptors.
+func (h Header) HeaderLen() uint16 {
+	return h.headerLen
+}
+
 // RecordLen returns the size in bytes of each record in the file.
 func (h Header) RecordLen() uint16 {
 	return h.recLen
@@ -65,6 +130,15 @@ func (h Header) NumRecords() uint32 {
 	return h.numRecs
 }
 
+// FieldDescs returns the field descriptors, in order.
+func (h Header) FieldDescs() []field.Desc {
+	out := make([]field.Desc, len(h.Fields))
+	for i, f := range h.Fields {
+		out[i] = f
+	}
+	return out
+}
+
 func (h Header) FieldExists(name string) bool {
 	for _, field := range h.Fields {
 		if field.name == name {
@@ -1,6 +1,7 @@
 package dbase5
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/everystreet/go-shapefile/dbf/field"
@@ -8,24 +9,13 @@ import (
 )
 
 // Record represents a single record, primarly consisting of a set of fields.
-type Record struct {
-	Fields map[string]Field
-
-	deleted bool
-}
+type Record = field.Record
 
 // Field provides common information about all field types.
-type Field interface {
-	Name() string
-	Value() interface{}
-	Equal(string) bool
-}
+type Field = field.Value
 
 // Config provides config for record parsing.
-type Config interface {
-	CharacterDecoder() *encoding.Decoder
-	FilteredFields() []string
-}
+type Config = field.Config
 
 // DecodeRecord decodes a dBase 5 single record.
 func DecodeRecord(buf []byte, header *Header, conf Config) (*Record, error) {
@@ -33,30 +23,22 @@ func DecodeRecord(buf []byte, header *Header, conf Config) (*Record, error) {
 		return nil, fmt.Errorf("expecting 1 byte but have %d", len(buf))
 	}
 
-	rec := &Record{
-		Fields: make(map[string]Field, len(header.Fields)-len(conf.FilteredFields())),
-	}
-
-	switch buf[0] {
-	case 0x20:
-		rec.deleted = false
-	case 0x2A:
-		rec.deleted = true
-	default:
-		return nil, fmt.Errorf("missing deletion flag")
+	rec, err := field.NewRecord(buf[0], len(header.Fields)-len(conf.FilteredFields()))
+	if err != nil {
+		return nil, err
 	}
 
 	pos := 1
 	for i, desc := range header.Fields {
 		if len(buf) < (pos + int(desc.len)) {
-			return nil, fmt.Errorf(fieldDecodeErr, desc.name, i,
+			return nil, field.DecodeError(desc.name, i,
 				fmt.Errorf("expecting %d bytes but have %d", desc.len, len(buf)-pos))
 		}
 		start, end := pos, pos+int(desc.len)
 		pos += int(desc.len)
 
 		// filter out unwanted fields
-		if !wantField(desc.name, conf.FilteredFields()) {
+		if !field.Wanted(desc.name, conf.FilteredFields()) {
 			continue
 		}
 
@@ -67,18 +49,22 @@ func DecodeRecord(buf []byte, header *Header, conf Config) (*Record, error) {
 		case CharacterType:
 			f, err = field.DecodeCharacter(buf[start:end], desc.name, conf.CharacterDecoder())
 		case DateType:
-			f, err = field.DecodeDate(buf[start:end], desc.name)
+			f, err = field.DecodeDate(buf[start:end], desc.name, conf.ParseOptions())
 		case FloatingPointType:
-			f, err = field.DecodeFloatingPoint(buf[start:end], desc.name)
+			f, err = field.DecodeFloatingPoint(buf[start:end], desc.name, conf.ParseOptions())
+		case LogicalType:
+			f, err = field.DecodeLogical(buf[start:end], desc.name)
+		case MemoType:
+			f, err = field.DecodeMemo(buf[start:end], desc.name, conf.MemoFile(), conf.CharacterDecoder())
 		case NumericType:
-			f, err = field.DecodeNumeric(buf[start:end], desc.name)
+			f, err = field.DecodeNumeric(buf[start:end], desc.name, conf.ParseOptions())
 		default:
-			return nil, fmt.Errorf(fieldDecodeErr, desc.name, i,
+			return nil, field.DecodeError(desc.name, i,
 				fmt.Errorf("unsupported field type '%c'", desc.Type))
 		}
 
 		if err != nil {
-			return nil, fmt.Errorf(fieldDecodeErr, desc.name, i, err)
+			return nil, field.DecodeError(desc.name, i, err)
 		}
 		rec.Fields[f.Name()] = f
 	}
@@ -86,22 +72,58 @@ func DecodeRecord(buf []byte, header *Header, conf Config) (*Record, error) {
 	return rec, nil
 }
 
-// Deleted returns the value of the deleted flag.
-func (r Record) Deleted() bool {
-	return r.deleted
-}
-
-func wantField(name string, filtered []string) bool {
-	if len(filtered) == 0 {
-		return true
+// EncodeRecord encodes a single record, where values are in the same order as the header fields.
+// Supported values are nil, string, float64, int64 and bool. Nil values are encoded as null bytes for character fields,
+// which is how DecodeCharacter recognises a null, and as blanks for every other type.
+func EncodeRecord(values []interface{}, header *Header, encoder *encoding.Encoder) ([]byte, error) {
+	if len(values) != len(header.Fields) {
+		return nil, fmt.Errorf("expecting %d values but have %d", len(header.Fields), len(values))
 	}
 
-	for _, f := range filtered {
-		if f == name {
-			return true
+	buf := make([]byte, 1, header.recLen)
+	buf[0] = 0x20 // not deleted
+
+	for i, desc := range header.Fields {
+		var val []byte
+		var err error
+
+		switch v := values[i].(type) {
+		case nil:
+			blank := byte(' ')
+			if desc.Type == CharacterType {
+				blank = 0x00
+			}
+			val = bytes.Repeat([]byte{blank}, int(desc.len))
+		case string:
+			if desc.Type != CharacterType {
+				return nil, fmt.Errorf(fieldEncodeErr, desc.name, i, fmt.Errorf("can't encode string as '%c'", desc.Type))
+			}
+			val, err = field.EncodeCharacter(v, int(desc.len), encoder)
+		case float64:
+			if desc.Type != NumericType && desc.Type != FloatingPointType {
+				return nil, fmt.Errorf(fieldEncodeErr, desc.name, i, fmt.Errorf("can't encode number as '%c'", desc.Type))
+			}
+			val, err = field.EncodeNumeric(v, int(desc.len), int(desc.decimals))
+		case int64:
+			if desc.Type != NumericType && desc.Type != FloatingPointType {
+				return nil, fmt.Errorf(fieldEncodeErr, desc.name, i, fmt.Errorf("can't encode number as '%c'", desc.Type))
+			}
+			val, err = field.EncodeInteger(v, int(desc.len), int(desc.decimals))
+		case bool:
+			if desc.Type != LogicalType {
+				return nil, fmt.Errorf(fieldEncodeErr, desc.name, i, fmt.Errorf("can't encode bool as '%c'", desc.Type))
+			}
+			val = field.EncodeLogical(v)
+		default:
+			return nil, fmt.Errorf(fieldEncodeErr, desc.name, i, fmt.Errorf("unsupported value type %T", v))
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf(fieldEncodeErr, desc.name, i, err)
 		}
+		buf = append(buf, val...)
 	}
-	return false
+	return buf, nil
 }
 
-const fieldDecodeErr = "failed to decode field '%s' (%d): %w"
+const fieldEncodeErr = "failed to encode field '%s' (%d): %w"
@@ -0,0 +1,64 @@
+package dbase7
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// FieldType is the type of a field.
+type FieldType uint8
+
+// Field types for dBase Level 7.
+const (
+	AutoincrementType FieldType = '+'
+	BinaryType        FieldType = 'B'
+	CharacterType     FieldType = 'C'
+	DateType          FieldType = 'D'
+	DoubleType        FieldType = 'O'
+	FloatingPointType FieldType = 'F'
+	LogicalType       FieldType = 'L'
+	LongType          FieldType = 'I'
+	MemoType          FieldType = 'M'
+	NumericType       FieldType = 'N'
+	OLEType           FieldType = 'G'
+	TimestampType     FieldType = '@'
+)
+
+// FieldDesc represents a field descriptor consisting of a type, name and size in bytes.
+type FieldDesc struct {
+	Type FieldType
+
+	name     string
+	len      uint8
+	decimals uint8
+}
+
+// DecodeFieldDesc parses a single field descriptor.
+func DecodeFieldDesc(buf []byte) (*FieldDesc, error) {
+	if len(buf) < 48 {
+		return nil, fmt.Errorf("expecting 48 bytes but have %d", len(buf))
+	}
+
+	name := bytes.Trim(buf[0:32], "\x00")
+	return &FieldDesc{
+		Type:     FieldType(buf[32]),
+		name:     string(name),
+		len:      buf[33],
+		decimals: buf[34],
+	}, nil
+}
+
+// Name of the field.
+func (f FieldDesc) Name() string {
+	return f.name
+}
+
+// Length returns the size of the field in bytes.
+func (f FieldDesc) Length() uint8 {
+	return f.len
+}
+
+// Decimals returns the number of decimal places of a numeric field.
+func (f FieldDesc) Decimals() uint8 {
+	return f.decimals
+}
@@ -0,0 +1,116 @@
+package dbase7
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/everystreet/go-shapefile/dbf/field"
+)
+
+// Header represents a dBase 7 file header.
+type Header struct {
+	Fields []*FieldDesc
+
+	// LanguageDriver is the name of the language driver used to write character fields, such as "DB437US0".
+	LanguageDriver string
+
+	lastUpdate time.Time
+	ldid       uint8
+	headerLen  uint16
+	recLen     uint16
+	numRecs    uint32
+}
+
+// DecodeHeader parses a dBase 7 file header.
+// Field properties, which follow the field descriptors, are skipped.
+func DecodeHeader(r io.Reader) (*Header, error) {
+	// Read first 67 bytes after first byte
+	buf := make([]byte, 67)
+	if n, err := io.ReadFull(r, buf); err != nil {
+		return nil, fmt.Errorf("read %d bytes but expecting %d: %w", n, len(buf), err)
+	}
+
+	out := &Header{
+		LanguageDriver: string(bytes.Trim(buf[31:63], "\x00\x20")),
+		lastUpdate:     field.DecodeHeaderDate(buf[0:3]),
+		ldid:           buf[28],
+		headerLen:      binary.LittleEndian.Uint16(buf[7:9]),
+		recLen:         binary.LittleEndian.Uint16(buf[9:11]),
+		numRecs:        binary.LittleEndian.Uint32(buf[3:7]),
+	}
+
+	// Read remainder of header
+	headerLen := int(out.headerLen)
+	if headerLen <= len(buf)+1 {
+		return nil, fmt.Errorf("invalid header size %d bytes", headerLen)
+	}
+
+	buf = make([]byte, headerLen-len(buf)-1)
+	if n, err := io.ReadFull(r, buf); err != nil {
+		return nil, fmt.Errorf("read %d bytes but expecting %d: %w", n, len(buf), err)
+	}
+
+	for i := 0; ; i++ {
+		pos := i * 48
+		if pos >= len(buf) {
+			return nil, fmt.Errorf("missing field descriptor terminator")
+		} else if buf[pos] == 0x0D {
+			break
+		}
+
+		f, err := DecodeFieldDesc(buf[pos:])
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode field %d: %w", i, err)
+		}
+		out.Fields = append(out.Fields, f)
+	}
+
+	return out, nil
+}
+
+// LastUpdate returns the date that the file was last updated.
+func (h Header) LastUpdate() time.Time {
+	return h.lastUpdate
+}
+
+// LanguageDriverID returns the language driver ID, which identifies the code page of character fields.
+// Zero means that it isn't set.
+func (h Header) LanguageDriverID() uint8 {
+	return h.ldid
+}
+
+// HeaderLen returns the size in bytes of the header, including the field descriptors and properties.
+func (h Header) HeaderLen() uint16 {
+	return h.headerLen
+}
+
+// RecordLen returns the size in bytes of each record in the file.
+func (h Header) RecordLen() uint16 {
+	return h.recLen
+}
+
+// NumRecords returns the number of records in the file.
+func (h Header) NumRecords() uint32 {
+	return h.numRecs
+}
+
+// FieldDescs returns the field descriptors, in order.
+func (h Header) FieldDescs() []field.Desc {
+	out := make([]field.Desc, len(h.Fields))
+	for i, f := range h.Fields {
+		out[i] = f
+	}
+	return out
+}
+
+func (h Header) FieldExists(name string) bool {
+	for _, field := range h.Fields {
+		if field.name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+package dbase7
+
+import (
+	"fmt"
+
+	"github.com/everystreet/go-shapefile/dbf/field"
+)
+
+// DecodeRecord decodes a dBase 7 single record.
+func DecodeRecord(buf []byte, header *Header, conf field.Config) (*field.Record, error) {
+	if len(buf) < 1 {
+		return nil, fmt.Errorf("expecting 1 byte but have %d", len(buf))
+	}
+
+	rec, err := field.NewRecord(buf[0], len(header.Fields))
+	if err != nil {
+		return nil, err
+	}
+
+	pos := 1
+	for i, desc := range header.Fields {
+		if len(buf) < (pos + int(desc.len)) {
+			return nil, field.DecodeError(desc.name, i,
+				fmt.Errorf("expecting %d bytes but have %d", desc.len, len(buf)-pos))
+		}
+		start, end := pos, pos+int(desc.len)
+		pos += int(desc.len)
+
+		// filter out unwanted fields
+		if !field.Wanted(desc.name, conf.FilteredFields()) {
+			continue
+		}
+
+		var f field.Value
+		var err error
+
+		switch desc.Type {
+		case AutoincrementType:
+			f, err = field.DecodeAutoincrement(buf[start:end], desc.name)
+		case BinaryType, OLEType:
+			f, err = field.DecodeBinaryMemo(buf[start:end], desc.name, conf.MemoFile())
+		case CharacterType:
+			f, err = field.DecodeCharacter(buf[start:end], desc.name, conf.CharacterDecoder())
+		case DateType:
+			f, err = field.DecodeDate(buf[start:end], desc.name, conf.ParseOptions())
+		case DoubleType:
+			f, err = field.DecodeDouble(buf[start:end], desc.name)
+		case FloatingPointType:
+			f, err = field.DecodeFloatingPoint(buf[start:end], desc.name, conf.ParseOptions())
+		case LogicalType:
+			f, err = field.DecodeLogical(buf[start:end], desc.name)
+		case LongType:
+			f, err = field.DecodeLong(buf[start:end], desc.name)
+		case MemoType:
+			f, err = field.DecodeMemo(buf[start:end], desc.name, conf.MemoFile(), conf.CharacterDecoder())
+		case NumericType:
+			f, err = field.DecodeNumeric(buf[start:end], desc.name, conf.ParseOptions())
+		case TimestampType:
+			f, err = field.DecodeTimestamp(buf[start:end], desc.name)
+		default:
+			return nil, field.DecodeError(desc.name, i,
+				fmt.Errorf("unsupported field type '%c'", desc.Type))
+		}
+
+		if err != nil {
+			return nil, field.DecodeError(desc.name, i, err)
+		}
+		rec.Fields[f.Name()] = f
+	}
+
+	return rec, nil
+}
@@ -0,0 +1,107 @@
+package dbf
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/transform"
+)
+
+// Charset is the kind of character set found in character fields by DetectCharset.
+type Charset uint8
+
+// Charsets.
+const (
+	// CharsetASCII means that no characters outside of the ASCII range were found.
+	CharsetASCII Charset = iota
+
+	// CharsetUTF8 means that characters outside of the ASCII range were found, and all values are valid UTF-8.
+	CharsetUTF8
+
+	// CharsetLegacy means that some values aren't valid UTF-8, so a legacy code page such as Windows-1252 is in use.
+	CharsetLegacy
+)
+
+// sampleSize is the maximum number of bytes of records that are sampled by DetectCharset.
+const sampleSize = 64 * 1024
+
+// DetectCharset guesses the character set of character and memo fields from the records in the first
+// 64KB of the file, without consuming them. It must be called before Scan.
+// The options are those that will be passed to Scan, although CharacterDecoder is ignored.
+func (s *Scanner) DetectCharset(opts ...Option) (Charset, error) {
+	conf := defaultConfig()
+	for _, opt := range opts {
+		opt(&conf)
+	}
+
+	if _, err := s.Header(); err != nil {
+		return CharsetASCII, fmt.Errorf("failed to parse header: %w", err)
+	}
+
+	recLen := int(s.header.RecordLen())
+	size := int(s.header.NumRecords()) * recLen
+	if size > sampleSize {
+		size = sampleSize - sampleSize%recLen
+	}
+
+	buf, err := s.in.Peek(size)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return CharsetASCII, fmt.Errorf("failed to read records: %w", err)
+	}
+
+	r := &recorder{}
+	sample := sampleConfig{
+		config: conf,
+		dec:    &encoding.Decoder{Transformer: r},
+	}
+
+	// Records that can't be decoded are skipped, leaving Scan to report the error
+	for ; len(buf) >= recLen && recLen > 0; buf = buf[recLen:] {
+		_, _ = s.decode(buf[:recLen], sample)
+	}
+	return r.charset, nil
+}
+
+// sampleConfig records the values of character fields instead of decoding them.
+type sampleConfig struct {
+	config
+	dec *encoding.Decoder
+}
+
+func (c sampleConfig) CharacterDecoder() *encoding.Decoder {
+	return c.dec
+}
+
+// recorder is a transformer that copies its input, updating the charset of the values it has seen.
+type recorder struct {
+	transform.NopResetter
+	charset Charset
+}
+
+func (r *recorder) Transform(dst, src []byte, atEOF bool) (int, int, error) {
+	if len(dst) < len(src) {
+		return 0, 0, transform.ErrShortDst
+	}
+	n := copy(dst, src)
+
+	switch {
+	case r.charset == CharsetLegacy:
+	case !utf8.Valid(src):
+		r.charset = CharsetLegacy
+	case !isASCII(src):
+		r.charset = CharsetUTF8
+	}
+	return n, n, nil
+}
+
+func isASCII(b []byte) bool {
+	for _, c := range b {
+		if c >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
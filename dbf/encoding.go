@@ -0,0 +1,93 @@
+package dbf
+
+import (
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/korean"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
+	"golang.org/x/text/encoding/unicode"
+)
+
+// Encoding is a named character encoding.
+type Encoding struct {
+	Name string
+	encoding.Encoding
+}
+
+// Encodings that are commonly used for character fields.
+var (
+	UTF8        = Encoding{"utf-8", unicode.UTF8}
+	Windows1252 = Encoding{"windows-1252", charmap.Windows1252}
+)
+
+// languageDrivers maps language driver IDs to encodings, where golang.org/x/text supports the code page.
+// 0x57 (ANSI) is left out because it means the system code page of whoever wrote the file, and many writers
+// use it for UTF-8 values. DetectCharacterEncoding chooses between UTF-8 and Windows-1252 for such files.
+var languageDrivers = map[uint8]Encoding{
+	0x01: {"ibm437", charmap.CodePage437},
+	0x02: {"ibm850", charmap.CodePage850},
+	0x03: Windows1252,
+	0x04: {"macintosh", charmap.Macintosh},
+	0x08: {"ibm865", charmap.CodePage865},
+	0x09: {"ibm437", charmap.CodePage437},
+	0x0A: {"ibm850", charmap.CodePage850},
+	0x0B: {"ibm437", charmap.CodePage437},
+	0x0D: {"ibm437", charmap.CodePage437},
+	0x0E: {"ibm850", charmap.CodePage850},
+	0x0F: {"ibm437", charmap.CodePage437},
+	0x10: {"ibm850", charmap.CodePage850},
+	0x11: {"ibm437", charmap.CodePage437},
+	0x12: {"ibm850", charmap.CodePage850},
+	0x13: {"shift_jis", japanese.ShiftJIS},
+	0x14: {"ibm850", charmap.CodePage850},
+	0x15: {"ibm437", charmap.CodePage437},
+	0x16: {"ibm850", charmap.CodePage850},
+	0x17: {"ibm865", charmap.CodePage865},
+	0x18: {"ibm437", charmap.CodePage437},
+	0x19: {"ibm437", charmap.CodePage437},
+	0x1A: {"ibm850", charmap.CodePage850},
+	0x1B: {"ibm437", charmap.CodePage437},
+	0x1C: {"ibm863", charmap.CodePage863},
+	0x1D: {"ibm850", charmap.CodePage850},
+	0x1F: {"ibm852", charmap.CodePage852},
+	0x22: {"ibm852", charmap.CodePage852},
+	0x23: {"ibm852", charmap.CodePage852},
+	0x24: {"ibm860", charmap.CodePage860},
+	0x25: {"ibm850", charmap.CodePage850},
+	0x26: {"ibm866", charmap.CodePage866},
+	0x37: {"ibm850", charmap.CodePage850},
+	0x40: {"ibm852", charmap.CodePage852},
+	0x4D: {"gbk", simplifiedchinese.GBK},
+	0x4E: {"euc-kr", korean.EUCKR},
+	0x4F: {"big5", traditionalchinese.Big5},
+	0x50: {"windows-874", charmap.Windows874},
+	0x58: Windows1252,
+	0x59: Windows1252,
+	0x64: {"ibm852", charmap.CodePage852},
+	0x65: {"ibm866", charmap.CodePage866},
+	0x66: {"ibm865", charmap.CodePage865},
+	0x6C: {"ibm863", charmap.CodePage863},
+	0x78: {"big5", traditionalchinese.Big5},
+	0x79: {"euc-kr", korean.EUCKR},
+	0x7A: {"gbk", simplifiedchinese.GBK},
+	0x7B: {"shift_jis", japanese.ShiftJIS},
+	0x7C: {"windows-874", charmap.Windows874},
+	0x7D: {"windows-1255", charmap.Windows1255},
+	0x7E: {"windows-1256", charmap.Windows1256},
+	0x87: {"ibm852", charmap.CodePage852},
+	0x96: {"x-mac-cyrillic", charmap.MacintoshCyrillic},
+	0xC8: {"windows-1250", charmap.Windows1250},
+	0xC9: {"windows-1251", charmap.Windows1251},
+	0xCA: {"windows-1254", charmap.Windows1254},
+	0xCB: {"windows-1253", charmap.Windows1253},
+	0xCC: {"windows-1257", charmap.Windows1257},
+}
+
+// LanguageDriverEncoding returns the encoding identified by a language driver ID, as returned by
+// Header.LanguageDriverID, and false if the ID isn't set or its code page is unsupported.
+func LanguageDriverEncoding(ldid uint8) (Encoding, bool) {
+	enc, ok := languageDrivers[ldid]
+	return enc, ok
+}
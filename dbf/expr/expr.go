@@ -0,0 +1,251 @@
+// Package expr implements a small expression language for selecting dbf records by their field values,
+// such as "POP_EST > 1000000 AND CONTINENT = 'Asia'".
+//
+// Fields can be compared with numbers, strings in single quotes, and TRUE or FALSE, using
+// =, != (or <>), <, <=, > and >=. Dates are compared with strings in the form 'YYYY-MM-DD'.
+// A field can be tested against a list of values with IN and NOT IN, and against empty values with IS NULL
+// and IS NOT NULL. Comparisons can be combined with AND, OR, NOT and parentheses.
+// Keywords are case-insensitive but field names and strings are not.
+package expr
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/everystreet/go-shapefile/dbf"
+)
+
+// Expr is a parsed expression.
+type Expr struct {
+	root   node
+	fields []string
+	src    string
+}
+
+// Fields provides access to the fields of a record by name, as implemented by dbf.Record.
+type Fields interface {
+	Field(string) (dbf.Field, bool)
+}
+
+// Parse parses an expression.
+func Parse(s string) (*Expr, error) {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse expression: %w", err)
+	}
+
+	p := parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse expression: %w", err)
+	} else if t := p.peek(); t.kind != eofToken {
+		return nil, fmt.Errorf("failed to parse expression: unexpected %s", t)
+	}
+
+	return &Expr{
+		root:   root,
+		fields: p.fields,
+		src:    s,
+	}, nil
+}
+
+// Eval returns true if the record's fields satisfy the expression.
+// An error is returned if a field is missing, or if its value can't be compared with a literal.
+func (e *Expr) Eval(fields Fields) (bool, error) {
+	return e.root.eval(fields)
+}
+
+// Fields returns the names of the fields used in the expression, in order of first use.
+func (e *Expr) Fields() []string {
+	return e.fields
+}
+
+// String returns the source of the expression.
+func (e *Expr) String() string {
+	return e.src
+}
+
+type node interface {
+	eval(Fields) (bool, error)
+}
+
+type orNode struct {
+	left, right node
+}
+
+func (n orNode) eval(fields Fields) (bool, error) {
+	if ok, err := n.left.eval(fields); err != nil || ok {
+		return ok, err
+	}
+	return n.right.eval(fields)
+}
+
+type andNode struct {
+	left, right node
+}
+
+func (n andNode) eval(fields Fields) (bool, error) {
+	if ok, err := n.left.eval(fields); err != nil || !ok {
+		return ok, err
+	}
+	return n.right.eval(fields)
+}
+
+type notNode struct {
+	n node
+}
+
+func (n notNode) eval(fields Fields) (bool, error) {
+	ok, err := n.n.eval(fields)
+	return !ok, err
+}
+
+type compareNode struct {
+	field string
+	op    string
+	value interface{}
+}
+
+// eval compares the field with the literal. Comparisons with null values are always false.
+func (n compareNode) eval(fields Fields) (bool, error) {
+	v, err := value(fields, n.field)
+	if err != nil || v == nil {
+		return false, err
+	}
+
+	cmp, err := compare(v, n.value)
+	if err != nil {
+		return false, fmt.Errorf("can't compare field '%s': %w", n.field, err)
+	}
+
+	switch n.op {
+	case "=":
+		return cmp == 0, nil
+	case "!=", "<>":
+		return cmp != 0, nil
+	}
+
+	if _, ok := v.(bool); ok {
+		return false, fmt.Errorf("can't compare field '%s': logical values have no order", n.field)
+	}
+
+	switch n.op {
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case ">":
+		return cmp > 0, nil
+	default:
+		return cmp >= 0, nil
+	}
+}
+
+type inNode struct {
+	field  string
+	values []interface{}
+	not    bool
+}
+
+func (n inNode) eval(fields Fields) (bool, error) {
+	v, err := value(fields, n.field)
+	if err != nil || v == nil {
+		return false, err
+	}
+
+	for _, lit := range n.values {
+		cmp, err := compare(v, lit)
+		if err != nil {
+			return false, fmt.Errorf("can't compare field '%s': %w", n.field, err)
+		} else if cmp == 0 {
+			return !n.not, nil
+		}
+	}
+	return n.not, nil
+}
+
+type nullNode struct {
+	field string
+	not   bool
+}
+
+func (n nullNode) eval(fields Fields) (bool, error) {
+	v, err := value(fields, n.field)
+	if err != nil {
+		return false, err
+	}
+	return (v == nil) != n.not, nil
+}
+
+// value returns the value of the named field, or nil if it's empty.
+func value(fields Fields, name string) (interface{}, error) {
+	f, ok := fields.Field(name)
+	if !ok {
+		return nil, fmt.Errorf("field '%s' does not exist", name)
+	}
+
+	switch v := f.Value().(type) {
+	case *time.Time:
+		if v == nil {
+			return nil, nil
+		}
+		return *v, nil
+	default:
+		return v, nil
+	}
+}
+
+// compare returns -1, 0 or 1 if the field value is less than, equal to, or greater than the literal.
+func compare(v, lit interface{}) (int, error) {
+	switch v := v.(type) {
+	case float64:
+		if l, ok := lit.(float64); ok {
+			return compareFloats(v, l), nil
+		}
+	case string:
+		if l, ok := lit.(string); ok {
+			return strings.Compare(v, l), nil
+		}
+	case bool:
+		if l, ok := lit.(bool); ok {
+			if v == l {
+				return 0, nil
+			}
+			return 1, nil
+		}
+	case time.Time:
+		if l, ok := lit.(string); ok {
+			date, err := parseDate(l)
+			if err != nil {
+				return 0, err
+			}
+
+			y, m, d := v.Date()
+			return compareFloats(float64(y*10000+int(m)*100+d), float64(date.Year()*10000+int(date.Month())*100+date.Day())), nil
+		}
+	default:
+		return 0, fmt.Errorf("unsupported value type %T", v)
+	}
+	return 0, fmt.Errorf("mismatched types %T and %T", v, lit)
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func parseDate(s string) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02", "20060102"} {
+		if date, err := time.Parse(layout, s); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date '%s'; expecting YYYY-MM-DD", s)
+}
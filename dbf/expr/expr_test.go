@@ -0,0 +1,123 @@
+package expr_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/everystreet/go-shapefile/dbf"
+	"github.com/everystreet/go-shapefile/dbf/expr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEval(t *testing.T) {
+	date := time.Date(2020, 6, 15, 0, 0, 0, 0, time.UTC)
+	rec := fields{
+		"POP_EST":   field{"POP_EST", 1500000.0},
+		"CONTINENT": field{"CONTINENT", "Asia"},
+		"NAME":      field{"NAME", "O'Brien"},
+		"CAPITAL":   field{"CAPITAL", true},
+		"FOUNDED":   field{"FOUNDED", &date},
+		"UPDATED":   field{"UPDATED", (*time.Time)(nil)},
+	}
+
+	for _, tt := range []struct {
+		expr string
+		want bool
+	}{
+		{"POP_EST > 1000000 AND CONTINENT = 'Asia'", true},
+		{"POP_EST > 1000000 and CONTINENT = 'Europe'", false},
+		{"POP_EST < 1000000 OR CONTINENT <> 'Europe'", true},
+		{"NOT (POP_EST >= 1500000)", false},
+		{"POP_EST <= 1.5e6", true},
+		{"CONTINENT IN ('Africa', 'Asia')", true},
+		{"CONTINENT NOT IN ('Africa', 'Asia')", false},
+		{"NAME = 'O''Brien'", true},
+		{"CAPITAL = TRUE", true},
+		{"CAPITAL != true", false},
+		{"FOUNDED > '2020-01-01' AND FOUNDED < '2021-01-01'", true},
+		{"FOUNDED = '20200615'", true},
+		{"UPDATED IS NULL", true},
+		{"FOUNDED IS NOT NULL", true},
+		{"UPDATED < '2020-01-01'", false},
+		{"POP_EST > 0 OR MISSING = 1", true},
+	} {
+		t.Run(tt.expr, func(t *testing.T) {
+			e, err := expr.Parse(tt.expr)
+			require.NoError(t, err)
+
+			ok, err := e.Eval(rec)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, ok)
+		})
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	rec := fields{
+		"POP_EST": field{"POP_EST", 1500000.0},
+		"CAPITAL": field{"CAPITAL", true},
+	}
+
+	for _, s := range []string{
+		"MISSING = 1",
+		"POP_EST = 'many'",
+		"CAPITAL > FALSE",
+	} {
+		t.Run(s, func(t *testing.T) {
+			e, err := expr.Parse(s)
+			require.NoError(t, err)
+
+			_, err = e.Eval(rec)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	e, err := expr.Parse("(A = 1 OR B = 'x') AND NOT A IN (2, 3) AND C IS NULL")
+	require.NoError(t, err)
+	require.Equal(t, []string{"A", "B", "C"}, e.Fields())
+
+	for _, s := range []string{
+		"",
+		"A",
+		"A = ",
+		"A == 1",
+		"A = 'open",
+		"(A = 1",
+		"A = 1 B = 2",
+		"A IN ()",
+		"A IS 1",
+		"AND = 1",
+		"A ! 1",
+	} {
+		t.Run(s, func(t *testing.T) {
+			_, err := expr.Parse(s)
+			require.Error(t, err)
+		})
+	}
+}
+
+type fields map[string]dbf.Field
+
+func (f fields) Field(name string) (dbf.Field, bool) {
+	v, ok := f[name]
+	return v, ok
+}
+
+type field struct {
+	name  string
+	value interface{}
+}
+
+func (f field) Name() string {
+	return f.name
+}
+
+func (f field) Value() interface{} {
+	return f.value
+}
+
+func (f field) Equal(string) bool {
+	return false
+}
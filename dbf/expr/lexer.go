@@ -0,0 +1,107 @@
+package expr
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind uint8
+
+const (
+	eofToken tokenKind = iota
+	identToken
+	numberToken
+	stringToken
+	operatorToken
+	lparenToken
+	rparenToken
+	commaToken
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// keyword returns true if the token is the specified keyword, regardless of case.
+func (t token) keyword(kw string) bool {
+	return t.kind == identToken && strings.EqualFold(t.text, kw)
+}
+
+func (t token) String() string {
+	if t.kind == eofToken {
+		return "end of expression"
+	}
+	return fmt.Sprintf("'%s' at position %d", t.text, t.pos+1)
+}
+
+// tokenize splits the input into tokens, ending with an eofToken.
+func tokenize(s string) ([]token, error) {
+	var out []token
+	runes := []rune(s)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		start := i
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+			continue
+		case r == '(':
+			out = append(out, token{lparenToken, "(", start})
+			i++
+		case r == ')':
+			out = append(out, token{rparenToken, ")", start})
+			i++
+		case r == ',':
+			out = append(out, token{commaToken, ",", start})
+			i++
+		case r == '=':
+			out = append(out, token{operatorToken, "=", start})
+			i++
+		case r == '<' || r == '>' || r == '!':
+			i++
+			if i < len(runes) && (runes[i] == '=' || (r == '<' && runes[i] == '>')) {
+				i++
+			}
+
+			op := string(runes[start:i])
+			if op == "!" {
+				return nil, fmt.Errorf("unexpected '!' at position %d", start+1)
+			}
+			out = append(out, token{operatorToken, op, start})
+		case r == '\'':
+			var b strings.Builder
+			for i++; ; i++ {
+				if i == len(runes) {
+					return nil, fmt.Errorf("unterminated string at position %d", start+1)
+				} else if runes[i] == '\'' {
+					// Quotes are escaped by doubling them
+					if i+1 < len(runes) && runes[i+1] == '\'' {
+						b.WriteRune('\'')
+						i++
+						continue
+					}
+					i++
+					break
+				}
+				b.WriteRune(runes[i])
+			}
+			out = append(out, token{stringToken, b.String(), start})
+		case unicode.IsDigit(r) || r == '-' || r == '.':
+			for i++; i < len(runes) && (unicode.IsDigit(runes[i]) || strings.ContainsRune(".eE", runes[i]) ||
+				((runes[i] == '-' || runes[i] == '+') && (runes[i-1] == 'e' || runes[i-1] == 'E'))); i++ {
+			}
+			out = append(out, token{numberToken, string(runes[start:i]), start})
+		case unicode.IsLetter(r) || r == '_':
+			for i++; i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_'); i++ {
+			}
+			out = append(out, token{identToken, string(runes[start:i]), start})
+		default:
+			return nil, fmt.Errorf("unexpected '%c' at position %d", r, start+1)
+		}
+	}
+	return append(out, token{eofToken, "", len(runes)}), nil
+}
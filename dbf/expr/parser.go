@@ -0,0 +1,197 @@
+package expr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parser is a recursive descent parser with the following grammar, where keywords are case-insensitive:
+//
+//	or         = and { "OR" and }
+//	and        = not { "AND" not }
+//	not        = "NOT" not | "(" or ")" | predicate
+//	predicate  = FIELD operator literal | FIELD [ "NOT" ] "IN" "(" literal { "," literal } ")" | FIELD "IS" [ "NOT" ] "NULL"
+//	operator   = "=" | "!=" | "<>" | "<" | "<=" | ">" | ">="
+//	literal    = NUMBER | STRING | "TRUE" | "FALSE"
+type parser struct {
+	tokens []token
+	pos    int
+	fields []string
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != eofToken {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) expect(kind tokenKind, what string) (token, error) {
+	t := p.next()
+	if t.kind != kind {
+		return t, fmt.Errorf("expecting %s but have %s", what, t)
+	}
+	return t, nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().keyword("OR") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().keyword("AND") {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (node, error) {
+	switch t := p.peek(); {
+	case t.keyword("NOT"):
+		p.next()
+		n, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{n}, nil
+	case t.kind == lparenToken:
+		p.next()
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(rparenToken, "')'"); err != nil {
+			return nil, err
+		}
+		return n, nil
+	default:
+		return p.parsePredicate()
+	}
+}
+
+func (p *parser) parsePredicate() (node, error) {
+	t, err := p.expect(identToken, "field name")
+	if err != nil {
+		return nil, err
+	} else if isKeyword(t.text) {
+		return nil, fmt.Errorf("expecting field name but have %s", t)
+	}
+
+	name := t.text
+	p.addField(name)
+
+	switch t := p.next(); {
+	case t.kind == operatorToken:
+		lit, err := p.parseLiteral()
+		if err != nil {
+			return nil, err
+		}
+		return compareNode{field: name, op: t.text, value: lit}, nil
+	case t.keyword("IS"):
+		not := p.peek().keyword("NOT")
+		if not {
+			p.next()
+		}
+		if t := p.next(); !t.keyword("NULL") {
+			return nil, fmt.Errorf("expecting NULL but have %s", t)
+		}
+		return nullNode{field: name, not: not}, nil
+	case t.keyword("IN"):
+		return p.parseIn(name, false)
+	case t.keyword("NOT"):
+		if t := p.next(); !t.keyword("IN") {
+			return nil, fmt.Errorf("expecting IN but have %s", t)
+		}
+		return p.parseIn(name, true)
+	default:
+		return nil, fmt.Errorf("expecting operator but have %s", t)
+	}
+}
+
+func (p *parser) parseIn(name string, not bool) (node, error) {
+	if _, err := p.expect(lparenToken, "'('"); err != nil {
+		return nil, err
+	}
+
+	n := inNode{field: name, not: not}
+	for {
+		lit, err := p.parseLiteral()
+		if err != nil {
+			return nil, err
+		}
+		n.values = append(n.values, lit)
+
+		t := p.next()
+		if t.kind == rparenToken {
+			return n, nil
+		} else if t.kind != commaToken {
+			return nil, fmt.Errorf("expecting ',' or ')' but have %s", t)
+		}
+	}
+}
+
+func (p *parser) parseLiteral() (interface{}, error) {
+	switch t := p.next(); {
+	case t.kind == numberToken:
+		f, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %s", t)
+		}
+		return f, nil
+	case t.kind == stringToken:
+		return t.text, nil
+	case t.keyword("TRUE"):
+		return true, nil
+	case t.keyword("FALSE"):
+		return false, nil
+	default:
+		return nil, fmt.Errorf("expecting number, string, TRUE or FALSE but have %s", t)
+	}
+}
+
+func (p *parser) addField(name string) {
+	for _, f := range p.fields {
+		if f == name {
+			return
+		}
+	}
+	p.fields = append(p.fields, name)
+}
+
+func isKeyword(s string) bool {
+	switch strings.ToUpper(s) {
+	case "AND", "OR", "NOT", "IN", "IS", "NULL", "TRUE", "FALSE":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,27 @@
+package field
+
+import "bytes"
+
+// Binary field is a string of bytes that isn't decoded as text.
+type Binary struct {
+	Field
+	Data []byte
+}
+
+// DecodeBinary decodes a single binary field, such as a Visual FoxPro varbinary field.
+func DecodeBinary(buf []byte, name string) (*Binary, error) {
+	return &Binary{
+		Field: Field{name: name},
+		Data:  append([]byte{}, buf...),
+	}, nil
+}
+
+// Value returns the field value.
+func (b Binary) Value() interface{} {
+	return b.Data
+}
+
+// Equal returns true if v contains the same bytes as b.
+func (b Binary) Equal(v string) bool {
+	return bytes.Equal([]byte(v), b.Data)
+}
@@ -8,29 +8,41 @@ import (
 	"golang.org/x/text/encoding"
 )
 
-// Character field is a string of characters.
+// Character field is a string of characters. Values that were never written are null,
+// in which case String is empty.
 type Character struct {
 	Field
 	String string
+	Null   bool
 }
 
 // DecodeCharacter decodes a single character field with the specified encoding.
+// A value that consists entirely of null bytes is null, whereas a value of spaces is an empty string.
 func DecodeCharacter(buf []byte, name string, decoder *encoding.Decoder) (*Character, error) {
+	out := &Character{
+		Field: Field{name: name},
+	}
+
 	val := bytes.Trim(buf, "\x00")
+	if len(val) == 0 && len(buf) > 0 {
+		out.Null = true
+		return out, nil
+	}
 
 	decVal, err := decoder.Bytes(val)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode value: %w", err)
 	}
 
-	return &Character{
-		Field:  Field{name: name},
-		String: strings.TrimSpace(string(decVal)),
-	}, nil
+	out.String = strings.TrimSpace(string(decVal))
+	return out, nil
 }
 
 // Value returns the field value.
 func (c Character) Value() interface{} {
+	if c.Null {
+		return nil
+	}
 	return c.String
 }
 
@@ -38,3 +50,15 @@ func (c Character) Value() interface{} {
 func (c Character) Equal(v string) bool {
 	return v == c.String
 }
+
+// EncodeCharacter encodes a single character field of the specified length, padded with spaces.
+// An error is returned if the encoded value is too long.
+func EncodeCharacter(s string, length int, encoder *encoding.Encoder) ([]byte, error) {
+	val, err := encoder.Bytes([]byte(s))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode value: %w", err)
+	} else if len(val) > length {
+		return nil, fmt.Errorf("value is %d bytes but field is %d", len(val), length)
+	}
+	return append(val, bytes.Repeat([]byte{' '}, length-len(val))...), nil
+}
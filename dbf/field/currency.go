@@ -0,0 +1,33 @@
+package field
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Currency field is a Visual FoxPro fixed point number with 4 decimal places.
+type Currency Numeric
+
+// DecodeCurrency decodes a single currency field, which is a little-endian 8 byte integer
+// holding the value multiplied by 10000.
+func DecodeCurrency(buf []byte, name string) (*Currency, error) {
+	if len(buf) != 8 {
+		return nil, fmt.Errorf("expecting 8 bytes but have %d", len(buf))
+	}
+
+	num := float64(int64(binary.LittleEndian.Uint64(buf))) / 10000
+	return &Currency{
+		Field:  Field{name: name},
+		Number: num,
+	}, nil
+}
+
+// Value returns the field value.
+func (c Currency) Value() interface{} {
+	return Numeric(c).Value()
+}
+
+// Equal returns true if v contains the same value as c.
+func (c Currency) Equal(v string) bool {
+	return Numeric(c).Equal(v)
+}
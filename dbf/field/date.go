@@ -2,29 +2,34 @@ package field
 
 import (
 	"bytes"
+	"fmt"
 	"time"
 )
 
-// Date field is a date with no time component.
+// Date field is a date with no time component, or nil if the value is blank.
 type Date struct {
 	Field
 	Date *time.Time
 }
 
 // DecodeDate decodes a single date field.
-func DecodeDate(buf []byte, name string) (*Date, error) {
+// Blank and zero values are null, as are values that can't be parsed if ParseOptions.InvalidAsNull is set.
+func DecodeDate(buf []byte, name string, opts ParseOptions) (*Date, error) {
 	val := bytes.Trim(buf, "\x00\x20")
 
 	out := &Date{
 		Field: Field{name: name},
 	}
 
-	if len(val) == 0 {
+	if len(val) == 0 || string(val) == "00000000" {
 		return out, nil
 	}
 
-	date, err := time.Parse("01/02/2006", string(val))
+	date, err := parseDate(string(val))
 	if err != nil {
+		if opts.InvalidAsNull {
+			return out, nil
+		}
 		return nil, err
 	}
 
@@ -34,17 +39,45 @@ func DecodeDate(buf []byte, name string) (*Date, error) {
 	}, nil
 }
 
+// DecodeHeaderDate decodes the YYMMDD last update date of a file header, where the year is an offset from 1900.
+// The zero time is returned if the month or day isn't set.
+func DecodeHeaderDate(buf []byte) time.Time {
+	if buf[1] == 0 || buf[2] == 0 {
+		return time.Time{}
+	}
+	return time.Date(1900+int(buf[0]), time.Month(buf[1]), int(buf[2]), 0, 0, 0, 0, time.UTC)
+}
+
 // Value returns the field value.
 func (d Date) Value() interface{} {
+	if d.Date == nil {
+		return nil
+	}
 	return d.Date
 }
 
 // Equal returns true if v contains the same value as c.
 func (d Date) Equal(v string) bool {
-	d2, err := time.Parse("01/02/2006", v)
+	if d.Date == nil {
+		return v == ""
+	}
+
+	d2, err := parseDate(v)
 	if err != nil {
 		return false
 	}
 
 	return d.Date.Year() == d2.Year() && d.Date.Month() == d2.Month() && d.Date.Day() == d2.Day()
 }
+
+// dateLayouts are the layouts accepted for dates. The dBase format is YYYYMMDD, but MM/DD/YYYY is also accepted.
+var dateLayouts = []string{"20060102", "01/02/2006"}
+
+func parseDate(s string) (time.Time, error) {
+	for _, layout := range dateLayouts {
+		if date, err := time.Parse(layout, s); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date '%s'", s)
+}
@@ -0,0 +1,54 @@
+package field
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// Double field is an 8 byte floating point number.
+type Double Numeric
+
+// DecodeDouble decodes a single double field. The value is stored big-endian, with the sign bit inverted for
+// positive numbers and every bit inverted for negative numbers, so that values sort as bytes.
+func DecodeDouble(buf []byte, name string) (*Double, error) {
+	if len(buf) != 8 {
+		return nil, fmt.Errorf("expecting 8 bytes but have %d", len(buf))
+	}
+
+	bits := binary.BigEndian.Uint64(buf)
+	if bits&(1<<63) != 0 {
+		bits &^= 1 << 63
+	} else {
+		bits = ^bits
+	}
+
+	num := math.Float64frombits(bits)
+	return &Double{
+		Field:  Field{name: name},
+		Number: num,
+	}, nil
+}
+
+// DecodeFoxProDouble decodes a single Visual FoxPro double field, which is a little-endian 8 byte float.
+func DecodeFoxProDouble(buf []byte, name string) (*Double, error) {
+	if len(buf) != 8 {
+		return nil, fmt.Errorf("expecting 8 bytes but have %d", len(buf))
+	}
+
+	num := math.Float64frombits(binary.LittleEndian.Uint64(buf))
+	return &Double{
+		Field:  Field{name: name},
+		Number: num,
+	}, nil
+}
+
+// Value returns the field value.
+func (d Double) Value() interface{} {
+	return Numeric(d).Value()
+}
+
+// Equal returns true if v contains the same value as d.
+func (d Double) Equal(v string) bool {
+	return Numeric(d).Equal(v)
+}
@@ -9,3 +9,19 @@ type Field struct {
 func (f Field) Name() string {
 	return f.name
 }
+
+// Desc provides common information about a field descriptor.
+type Desc interface {
+	Name() string
+	Length() uint8
+	Decimals() uint8
+}
+
+// ParseOptions control how numbers and dates stored as text are parsed.
+type ParseOptions struct {
+	// InvalidAsNull decodes values that can't be parsed as null, rather than returning an error.
+	InvalidAsNull bool
+
+	// DecimalSeparator separates the integer and fractional parts of numbers. If it's zero, '.' is used.
+	DecimalSeparator byte
+}
@@ -3,9 +3,9 @@ package field
 // FloatingPoint field.
 type FloatingPoint Numeric
 
-// DecodeFloatingPoint decodes a single floating point field.
-func DecodeFloatingPoint(buf []byte, name string) (*FloatingPoint, error) {
-	n, err := DecodeNumeric(buf, name)
+// DecodeFloatingPoint decodes a single floating point field, in the same way as DecodeNumeric.
+func DecodeFloatingPoint(buf []byte, name string, opts ParseOptions) (*FloatingPoint, error) {
+	n, err := DecodeNumeric(buf, name, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -14,7 +14,7 @@ func DecodeFloatingPoint(buf []byte, name string) (*FloatingPoint, error) {
 
 // Value returns the field value.
 func (f FloatingPoint) Value() interface{} {
-	return f.Number
+	return Numeric(f).Value()
 }
 
 // Equal returns true if v contains the same value as f.
@@ -0,0 +1,78 @@
+package field
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Logical field is a boolean, or nil if the value is uninitialised.
+type Logical struct {
+	Field
+	Bool *bool
+}
+
+// DecodeLogical decodes a single logical field.
+// T, t, Y and y are true, F, f, N and n are false, and ? or a blank value is uninitialised.
+func DecodeLogical(buf []byte, name string) (*Logical, error) {
+	val := bytes.Trim(buf, "\x00\x20")
+
+	out := &Logical{
+		Field: Field{name: name},
+	}
+
+	if len(val) == 0 {
+		return out, nil
+	} else if len(val) > 1 {
+		return nil, fmt.Errorf("invalid logical value '%s'", string(val))
+	}
+
+	var b bool
+	switch val[0] {
+	case 'T', 't', 'Y', 'y':
+		b = true
+	case 'F', 'f', 'N', 'n':
+		b = false
+	case '?':
+		return out, nil
+	default:
+		return nil, fmt.Errorf("invalid logical value '%s'", string(val))
+	}
+
+	out.Bool = &b
+	return out, nil
+}
+
+// EncodeLogical encodes a single logical field as 'T' or 'F'.
+func EncodeLogical(b bool) []byte {
+	if b {
+		return []byte{'T'}
+	}
+	return []byte{'F'}
+}
+
+// Value returns the field value, which is nil if the value is uninitialised.
+func (l Logical) Value() interface{} {
+	if l.Bool == nil {
+		return nil
+	}
+	return *l.Bool
+}
+
+// Equal returns true if v contains the same value as l.
+// v may be "true" or "false", or any of the single character values.
+// An uninitialised value is only equal to an empty string or "?".
+func (l Logical) Equal(v string) bool {
+	if l.Bool == nil {
+		return v == "" || v == "?"
+	}
+
+	switch strings.ToLower(v) {
+	case "true", "t", "y":
+		return *l.Bool
+	case "false", "f", "n":
+		return !*l.Bool
+	default:
+		return false
+	}
+}
@@ -0,0 +1,73 @@
+package field
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+)
+
+// Long field is a 4 byte integer, stored big-endian with the sign bit inverted so that values sort as bytes.
+type Long struct {
+	Field
+	Number float64
+}
+
+// DecodeLong decodes a single long field.
+func DecodeLong(buf []byte, name string) (*Long, error) {
+	if len(buf) != 4 {
+		return nil, fmt.Errorf("expecting 4 bytes but have %d", len(buf))
+	}
+
+	return &Long{
+		Field:  Field{name: name},
+		Number: float64(int32(binary.BigEndian.Uint32(buf) ^ 0x80000000)),
+	}, nil
+}
+
+// DecodeFoxProInteger decodes a single Visual FoxPro integer field, which is a little-endian 4 byte integer.
+func DecodeFoxProInteger(buf []byte, name string) (*Long, error) {
+	if len(buf) != 4 {
+		return nil, fmt.Errorf("expecting 4 bytes but have %d", len(buf))
+	}
+
+	return &Long{
+		Field:  Field{name: name},
+		Number: float64(int32(binary.LittleEndian.Uint32(buf))),
+	}, nil
+}
+
+// Value returns the field value.
+func (l Long) Value() interface{} {
+	return l.Number
+}
+
+// Equal returns true if v contains the same value as l.
+func (l Long) Equal(v string) bool {
+	n, err := strconv.ParseInt(v, 10, 32)
+	if err != nil {
+		return false
+	}
+	return float64(n) == l.Number
+}
+
+// Autoincrement field is a long that is set automatically when a record is added.
+type Autoincrement Long
+
+// DecodeAutoincrement decodes a single autoincrement field.
+func DecodeAutoincrement(buf []byte, name string) (*Autoincrement, error) {
+	l, err := DecodeLong(buf, name)
+	if err != nil {
+		return nil, err
+	}
+	return (*Autoincrement)(l), nil
+}
+
+// Value returns the field value.
+func (a Autoincrement) Value() interface{} {
+	return a.Number
+}
+
+// Equal returns true if v contains the same value as a.
+func (a Autoincrement) Equal(v string) bool {
+	return Long(a).Equal(v)
+}
@@ -0,0 +1,158 @@
+package field
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/everystreet/go-shapefile/dbf/memo"
+	"golang.org/x/text/encoding"
+)
+
+// Memo field is text or binary data stored in a separate memo file.
+type Memo struct {
+	Field
+	Text string
+
+	// Data holds the value of a binary memo, and is nil for text.
+	Data []byte
+}
+
+// DecodeMemo decodes a single memo field, which refers to a block in the memo file by a number stored as text.
+// Text memos are decoded with the specified encoding. An empty field doesn't require a memo file.
+func DecodeMemo(buf []byte, name string, file *memo.File, decoder *encoding.Decoder) (*Memo, error) {
+	block, err := textMemoBlock(buf)
+	if err != nil {
+		return nil, err
+	}
+	return decodeMemo(block, name, file, decoder)
+}
+
+// DecodeFoxProMemo decodes a single Visual FoxPro memo field like DecodeMemo,
+// except that the block number is a little-endian 4 byte integer.
+func DecodeFoxProMemo(buf []byte, name string, file *memo.File, decoder *encoding.Decoder) (*Memo, error) {
+	block, err := binaryMemoBlock(buf)
+	if err != nil {
+		return nil, err
+	}
+	return decodeMemo(block, name, file, decoder)
+}
+
+func decodeMemo(block uint32, name string, file *memo.File, decoder *encoding.Decoder) (*Memo, error) {
+	m, err := readMemo(block, file)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &Memo{
+		Field: Field{name: name},
+	}
+
+	if m == nil {
+		return out, nil
+	} else if !m.Text {
+		out.Data = m.Data
+		return out, nil
+	}
+
+	text, err := decoder.Bytes(m.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode value: %w", err)
+	}
+	out.Text = strings.TrimRight(string(text), "\x00")
+	return out, nil
+}
+
+// Value returns the field value, which is a []byte for binary memos and a string otherwise.
+func (m Memo) Value() interface{} {
+	if m.Data != nil {
+		return m.Data
+	}
+	return m.Text
+}
+
+// Equal returns true if v contains the same value as m.
+func (m Memo) Equal(v string) bool {
+	if m.Data != nil {
+		return v == string(m.Data)
+	}
+	return v == m.Text
+}
+
+// DecodeBinaryMemo decodes a single memo field that holds binary data, such as a dBase Level 7 binary or OLE
+// field. The value is never decoded as text.
+func DecodeBinaryMemo(buf []byte, name string, file *memo.File) (*Memo, error) {
+	block, err := textMemoBlock(buf)
+	if err != nil {
+		return nil, err
+	}
+	return decodeBinaryMemo(block, name, file)
+}
+
+// DecodeFoxProBinaryMemo decodes a single Visual FoxPro blob or general field like DecodeBinaryMemo,
+// except that the block number is a little-endian 4 byte integer.
+func DecodeFoxProBinaryMemo(buf []byte, name string, file *memo.File) (*Memo, error) {
+	block, err := binaryMemoBlock(buf)
+	if err != nil {
+		return nil, err
+	}
+	return decodeBinaryMemo(block, name, file)
+}
+
+func decodeBinaryMemo(block uint32, name string, file *memo.File) (*Memo, error) {
+	m, err := readMemo(block, file)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &Memo{
+		Field: Field{name: name},
+	}
+
+	if m != nil {
+		out.Data = m.Data
+		if out.Data == nil {
+			out.Data = []byte{}
+		}
+	}
+	return out, nil
+}
+
+// textMemoBlock parses a block number stored as text, which is zero if the field is blank.
+func textMemoBlock(buf []byte) (uint32, error) {
+	val := bytes.Trim(buf, "\x00\x20")
+	if len(val) == 0 {
+		return 0, nil
+	}
+
+	block, err := strconv.ParseUint(string(val), 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse memo block '%s': %w", string(val), err)
+	}
+	return uint32(block), nil
+}
+
+// binaryMemoBlock parses a block number stored as a little-endian 4 byte integer.
+func binaryMemoBlock(buf []byte) (uint32, error) {
+	if len(buf) != 4 {
+		return 0, fmt.Errorf("expecting 4 bytes but have %d", len(buf))
+	}
+	return binary.LittleEndian.Uint32(buf), nil
+}
+
+// readMemo reads the memo in the specified block, or returns nil if the block is zero.
+func readMemo(block uint32, file *memo.File) (*memo.Memo, error) {
+	if block == 0 {
+		return nil, nil
+	} else if file == nil {
+		return nil, fmt.Errorf("missing memo file for block %d", block)
+	}
+
+	m, err := file.Read(block)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
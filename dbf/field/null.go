@@ -0,0 +1,23 @@
+package field
+
+// Null field is a field of any type that has no value.
+type Null struct {
+	Field
+}
+
+// NewNull creates a null field.
+func NewNull(name string) *Null {
+	return &Null{
+		Field: Field{name: name},
+	}
+}
+
+// Value returns nil.
+func (n Null) Value() interface{} {
+	return nil
+}
+
+// Equal returns true if v is empty.
+func (n Null) Equal(v string) bool {
+	return v == ""
+}
@@ -4,35 +4,83 @@ import (
 	"bytes"
 	"fmt"
 	"strconv"
+	"strings"
 )
 
-// Numeric field.
+// Numeric field is a number. Blank values are null, in which case Number is zero.
 type Numeric struct {
 	Field
 	Number float64
+	Null   bool
+}
+
+// EncodeNumeric encodes a single numeric field of the specified length and number of decimal places,
+// right-aligned and padded with spaces. An error is returned if the encoded value is too long.
+func EncodeNumeric(n float64, length, decimals int) ([]byte, error) {
+	return padNumeric(strconv.FormatFloat(n, 'f', decimals, 64), length)
+}
+
+// EncodeInteger encodes a single numeric field like EncodeNumeric, but without the loss of precision
+// of converting large integers to float64.
+func EncodeInteger(n int64, length, decimals int) ([]byte, error) {
+	val := strconv.FormatInt(n, 10)
+	if decimals > 0 {
+		val += "." + strings.Repeat("0", decimals)
+	}
+	return padNumeric(val, length)
+}
+
+func padNumeric(val string, length int) ([]byte, error) {
+	if len(val) > length {
+		return nil, fmt.Errorf("value '%s' is %d bytes but field is %d", val, len(val), length)
+	}
+	return append(bytes.Repeat([]byte{' '}, length-len(val)), val...), nil
 }
 
 // DecodeNumeric decodes a single numeric field.
-func DecodeNumeric(buf []byte, name string) (*Numeric, error) {
-	val := bytes.Trim(buf, "\x20") // trim spaces
-	num, err := strconv.ParseFloat(string(val), 64)
+// Blank values are null, as are values that can't be parsed if ParseOptions.InvalidAsNull is set.
+func DecodeNumeric(buf []byte, name string, opts ParseOptions) (*Numeric, error) {
+	out := &Numeric{
+		Field: Field{name: name},
+		Null:  true,
+	}
+
+	val := bytes.Trim(buf, "\x00\x20") // trim nulls and spaces
+	if len(val) == 0 {
+		return out, nil
+	}
+
+	text := string(val)
+	if sep := opts.DecimalSeparator; sep != 0 && sep != '.' {
+		text = strings.Replace(text, string(sep), ".", 1)
+	}
+
+	num, err := strconv.ParseFloat(text, 64)
 	if err != nil {
+		if opts.InvalidAsNull {
+			return out, nil
+		}
 		return nil, fmt.Errorf("failed to parse number '%s': %w", string(val), err)
 	}
 
-	return &Numeric{
-		Field:  Field{name: name},
-		Number: num,
-	}, nil
+	out.Number, out.Null = num, false
+	return out, nil
 }
 
 // Value returns the field value.
 func (n Numeric) Value() interface{} {
+	if n.Null {
+		return nil
+	}
 	return n.Number
 }
 
 // Equal returns true if v contains the same value as n.
 func (n Numeric) Equal(v string) bool {
+	if n.Null {
+		return v == ""
+	}
+
 	f, err := strconv.ParseFloat(v, 64)
 	if err != nil {
 		return false
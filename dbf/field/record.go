@@ -0,0 +1,72 @@
+package field
+
+import (
+	"fmt"
+
+	"github.com/everystreet/go-shapefile/dbf/memo"
+	"golang.org/x/text/encoding"
+)
+
+// Record represents a single record, primarly consisting of a set of fields.
+// It's shared by the decoders for each dBase version.
+type Record struct {
+	Fields map[string]Value
+
+	deleted bool
+}
+
+// Value provides common information about all field types.
+type Value interface {
+	Name() string
+	Value() interface{}
+	Equal(string) bool
+}
+
+// Config provides config for record parsing.
+type Config interface {
+	CharacterDecoder() *encoding.Decoder
+	FilteredFields() []string
+	MemoFile() *memo.File
+	ParseOptions() ParseOptions
+}
+
+// NewRecord creates a record with no fields from the deletion flag, which is the first byte of each record.
+func NewRecord(flag byte, numFields int) (*Record, error) {
+	rec := &Record{
+		Fields: make(map[string]Value, numFields),
+	}
+
+	switch flag {
+	case 0x20:
+		rec.deleted = false
+	case 0x2A:
+		rec.deleted = true
+	default:
+		return nil, fmt.Errorf("missing deletion flag")
+	}
+	return rec, nil
+}
+
+// Deleted returns the value of the deleted flag.
+func (r Record) Deleted() bool {
+	return r.deleted
+}
+
+// Wanted returns true if the named field is one of the filtered fields, or if there aren't any.
+func Wanted(name string, filtered []string) bool {
+	if len(filtered) == 0 {
+		return true
+	}
+
+	for _, f := range filtered {
+		if f == name {
+			return true
+		}
+	}
+	return false
+}
+
+// DecodeError wraps an error that occurred while decoding the field at the specified index.
+func DecodeError(name string, index int, err error) error {
+	return fmt.Errorf("failed to decode field '%s' (%d): %w", name, index, err)
+}
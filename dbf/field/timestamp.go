@@ -0,0 +1,74 @@
+package field
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// Timestamp field is a date and time, or nil if the value is uninitialised.
+type Timestamp struct {
+	Field
+	Time *time.Time
+}
+
+// DecodeTimestamp decodes a single dBase Level 7 timestamp field, which is a big-endian Julian day number
+// followed by a big-endian number of milliseconds since midnight.
+func DecodeTimestamp(buf []byte, name string) (*Timestamp, error) {
+	if len(buf) != 8 {
+		return nil, fmt.Errorf("expecting 8 bytes but have %d", len(buf))
+	}
+
+	return &Timestamp{
+		Field: Field{name: name},
+		Time:  julianTime(binary.BigEndian.Uint32(buf[0:4]), binary.BigEndian.Uint32(buf[4:8])),
+	}, nil
+}
+
+// DecodeFoxProDateTime decodes a single Visual FoxPro datetime field, which is a little-endian Julian day number
+// followed by a little-endian number of milliseconds since midnight.
+func DecodeFoxProDateTime(buf []byte, name string) (*Timestamp, error) {
+	if len(buf) != 8 {
+		return nil, fmt.Errorf("expecting 8 bytes but have %d", len(buf))
+	}
+
+	return &Timestamp{
+		Field: Field{name: name},
+		Time:  julianTime(binary.LittleEndian.Uint32(buf[0:4]), binary.LittleEndian.Uint32(buf[4:8])),
+	}, nil
+}
+
+// Value returns the field value.
+func (t Timestamp) Value() interface{} {
+	if t.Time == nil {
+		return nil
+	}
+	return t.Time
+}
+
+// Equal returns true if v contains the same value as t, in RFC 3339 format.
+func (t Timestamp) Equal(v string) bool {
+	if t.Time == nil {
+		return v == ""
+	}
+
+	t2, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return false
+	}
+	return t.Time.Equal(t2)
+}
+
+// unixEpochJulianDay is the Julian day number of 1970-01-01.
+const unixEpochJulianDay = 2440588
+
+// julianTime returns the UTC time of a Julian day number and milliseconds since midnight,
+// or nil if the day is zero.
+func julianTime(day, ms uint32) *time.Time {
+	if day == 0 {
+		return nil
+	}
+
+	t := time.Unix((int64(day)-unixEpochJulianDay)*86400, int64(ms)*int64(time.Millisecond)).UTC()
+	return &t
+}
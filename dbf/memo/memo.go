@@ -0,0 +1,157 @@
+// Package memo reads the files that hold the values of memo fields,
+// which are dBase III and IV .dbt files, and FoxPro .fpt files.
+package memo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Format is the layout of a memo file.
+type Format uint8
+
+// Memo file formats.
+const (
+	// DBT is a dBase III or IV .dbt file. dBase IV blocks begin with a length, whereas dBase III blocks
+	// are terminated by 0x1A. Both are supported by the same file.
+	DBT Format = iota
+
+	// FPT is a FoxPro .fpt file, where blocks begin with a type and a length.
+	FPT
+)
+
+// File provides random access to the blocks of a memo file.
+type File struct {
+	r         io.ReaderAt
+	size      int64
+	format    Format
+	blockSize int64
+}
+
+// Memo is the value of a single memo.
+type Memo struct {
+	Data []byte
+
+	// Text is true if the memo holds text, and false if it holds binary data such as a picture or object.
+	Text bool
+}
+
+const (
+	dbtDefaultBlockSize = 512
+	fptDefaultBlockSize = 64
+)
+
+// NewDBT creates a File for a dBase III or IV .dbt memo file.
+func NewDBT(r io.ReaderAt, size int64) (*File, error) {
+	buf := make([]byte, 22)
+	if n, err := r.ReadAt(buf, 0); err != nil {
+		return nil, fmt.Errorf("read %d bytes but expecting %d: %w", n, len(buf), err)
+	}
+
+	blockSize := int64(binary.LittleEndian.Uint16(buf[20:22]))
+	if blockSize == 0 {
+		blockSize = dbtDefaultBlockSize
+	}
+
+	return &File{
+		r:         r,
+		size:      size,
+		format:    DBT,
+		blockSize: blockSize,
+	}, nil
+}
+
+// NewFPT creates a File for a FoxPro .fpt memo file.
+func NewFPT(r io.ReaderAt, size int64) (*File, error) {
+	buf := make([]byte, 8)
+	if n, err := r.ReadAt(buf, 0); err != nil {
+		return nil, fmt.Errorf("read %d bytes but expecting %d: %w", n, len(buf), err)
+	}
+
+	blockSize := int64(binary.BigEndian.Uint16(buf[6:8]))
+	if blockSize == 0 {
+		blockSize = fptDefaultBlockSize
+	}
+
+	return &File{
+		r:         r,
+		size:      size,
+		format:    FPT,
+		blockSize: blockSize,
+	}, nil
+}
+
+// Format returns the layout of the file.
+func (f *File) Format() Format {
+	return f.format
+}
+
+// Read returns the memo that starts at the specified block.
+func (f *File) Read(block uint32) (Memo, error) {
+	off := int64(block) * f.blockSize
+	if block == 0 || off >= f.size {
+		return Memo{}, fmt.Errorf("block %d is outside of memo file", block)
+	}
+
+	// A dBase III memo may be shorter than a block header
+	head := make([]byte, 8)
+	if n, err := f.r.ReadAt(head, off); err != nil && (err != io.EOF || f.format == FPT) {
+		return Memo{}, fmt.Errorf("read %d bytes but expecting %d: %w", n, len(head), err)
+	}
+
+	switch {
+	case f.format == FPT:
+		typ := binary.BigEndian.Uint32(head[0:4])
+		data, err := f.read(off+8, int64(binary.BigEndian.Uint32(head[4:8])))
+		return Memo{Data: data, Text: typ == 1}, err
+	case bytes.Equal(head[0:4], []byte{0xFF, 0xFF, 0x08, 0x00}):
+		// dBase IV length includes the 8 byte block header
+		length := int64(binary.LittleEndian.Uint32(head[4:8])) - 8
+		if length < 0 {
+			return Memo{}, fmt.Errorf("invalid memo length %d", length+8)
+		}
+
+		data, err := f.read(off+8, length)
+		return Memo{Data: data, Text: true}, err
+	default:
+		data, err := f.readTerminated(off)
+		return Memo{Data: data, Text: true}, err
+	}
+}
+
+func (f *File) read(off, length int64) ([]byte, error) {
+	if off+length > f.size {
+		return nil, fmt.Errorf("memo of %d bytes at offset %d is outside of memo file", length, off)
+	}
+
+	buf := make([]byte, length)
+	if n, err := f.r.ReadAt(buf, off); err != nil {
+		return nil, fmt.Errorf("read %d bytes but expecting %d: %w", n, len(buf), err)
+	}
+	return buf, nil
+}
+
+// readTerminated reads a dBase III memo, which ends at the first 0x1A or at the end of the file.
+func (f *File) readTerminated(off int64) ([]byte, error) {
+	var out []byte
+	buf := make([]byte, f.blockSize)
+	for off < f.size {
+		n, err := f.r.ReadAt(buf, off)
+		if err != nil && err != io.EOF {
+			return nil, fmt.Errorf("failed to read memo: %w", err)
+		}
+
+		if i := bytes.IndexByte(buf[:n], 0x1A); i != -1 {
+			return append(out, buf[:i]...), nil
+		}
+		out = append(out, buf[:n]...)
+		off += int64(n)
+
+		if n == 0 {
+			break
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,81 @@
+package memo_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/everystreet/go-shapefile/dbf/memo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDBT(t *testing.T) {
+	t.Run("dBase III", func(t *testing.T) {
+		buf := make([]byte, 512*3)
+		copy(buf[512:], append([]byte("first memo"), 0x1A, 0x1A))
+
+		// A memo that spans several blocks and ends with the file
+		long := bytes.Repeat([]byte("x"), 600)
+		buf = append(buf[:1024], long...)
+
+		f, err := memo.NewDBT(bytes.NewReader(buf), int64(len(buf)))
+		require.NoError(t, err)
+		require.Equal(t, memo.DBT, f.Format())
+
+		m, err := f.Read(1)
+		require.NoError(t, err)
+		require.Equal(t, memo.Memo{Data: []byte("first memo"), Text: true}, m)
+
+		m, err = f.Read(2)
+		require.NoError(t, err)
+		require.Equal(t, long, m.Data)
+
+		_, err = f.Read(0)
+		require.Error(t, err)
+		_, err = f.Read(5)
+		require.Error(t, err)
+	})
+
+	t.Run("dBase IV", func(t *testing.T) {
+		buf := make([]byte, 256*2)
+		binary.LittleEndian.PutUint16(buf[20:22], 256)
+
+		block := []byte{0xFF, 0xFF, 0x08, 0x00, 0, 0, 0, 0}
+		binary.LittleEndian.PutUint32(block[4:8], uint32(8+len("with\x1Alength")))
+		copy(buf[256:], append(block, "with\x1Alength"...))
+
+		f, err := memo.NewDBT(bytes.NewReader(buf), int64(len(buf)))
+		require.NoError(t, err)
+
+		m, err := f.Read(1)
+		require.NoError(t, err)
+		require.Equal(t, memo.Memo{Data: []byte("with\x1Alength"), Text: true}, m)
+	})
+}
+
+func TestFPT(t *testing.T) {
+	buf := make([]byte, 512, 640)
+	binary.BigEndian.PutUint16(buf[6:8], 64)
+	buf = append(buf, fptBlock(1, "text memo")...)
+	buf = append(buf, fptBlock(0, "\x89PNG")...)
+
+	f, err := memo.NewFPT(bytes.NewReader(buf), int64(len(buf)))
+	require.NoError(t, err)
+	require.Equal(t, memo.FPT, f.Format())
+
+	m, err := f.Read(8)
+	require.NoError(t, err)
+	require.Equal(t, memo.Memo{Data: []byte("text memo"), Text: true}, m)
+
+	m, err = f.Read(9)
+	require.NoError(t, err)
+	require.Equal(t, memo.Memo{Data: []byte("\x89PNG"), Text: false}, m)
+}
+
+func fptBlock(typ uint32, data string) []byte {
+	buf := make([]byte, 64)
+	binary.BigEndian.PutUint32(buf[0:4], typ)
+	binary.BigEndian.PutUint32(buf[4:8], uint32(len(data)))
+	copy(buf[8:], data)
+	return buf
+}
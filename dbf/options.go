@@ -1,6 +1,10 @@
 package dbf
 
-import "golang.org/x/text/encoding"
+import (
+	"github.com/everystreet/go-shapefile/dbf/field"
+	"github.com/everystreet/go-shapefile/dbf/memo"
+	"golang.org/x/text/encoding"
+)
 
 // Option funcs can be passed to Scanner.Scan().
 type Option func(*config)
@@ -22,10 +26,36 @@ func FilterFields(names ...string) Option {
 	}
 }
 
+// MemoFile sets the memo file that holds the values of memo fields.
+// Without this option, memo fields that refer to a block can't be decoded.
+func MemoFile(f *memo.File) Option {
+	return func(c *config) {
+		c.memo = f
+	}
+}
+
+// InvalidAsNull decodes numbers and dates that can't be parsed as null values, rather than failing.
+// Blank values are always null.
+func InvalidAsNull() Option {
+	return func(c *config) {
+		c.parse.InvalidAsNull = true
+	}
+}
+
+// DecimalSeparator sets the character that separates the integer and fractional parts of numbers,
+// such as ',' for files written with some locales. By default, '.' is assumed.
+func DecimalSeparator(sep byte) Option {
+	return func(c *config) {
+		c.parse.DecimalSeparator = sep
+	}
+}
+
 // Config for dbf parsing.
 type config struct {
 	charDec *encoding.Decoder
 	fields  []string
+	memo    *memo.File
+	parse   field.ParseOptions
 }
 
 // CharacterDecoder returns the configured encoding.
@@ -38,6 +68,16 @@ func (c config) FilteredFields() []string {
 	return c.fields
 }
 
+// MemoFile returns the configured memo file.
+func (c config) MemoFile() *memo.File {
+	return c.memo
+}
+
+// ParseOptions returns the configured options for parsing numbers and dates.
+func (c config) ParseOptions() field.ParseOptions {
+	return c.parse
+}
+
 func defaultConfig() config {
 	return config{
 		charDec: encoding.Nop.NewDecoder(),
@@ -5,8 +5,8 @@ import (
 	"io"
 	"strings"
 	"text/tabwriter"
+	"time"
 
-	"github.com/everystreet/go-shapefile/dbf/dbase5"
 	"github.com/olekukonko/tablewriter"
 )
 
@@ -107,55 +107,54 @@ func (p TablePrinter) PrettyPrint(out io.Writer) error {
 }
 
 func (p TablePrinter) header() ([]string, error) {
+	if len(p.fields) != 0 {
+		return p.fields, nil
+	}
+
 	info, err := p.scanner.Header()
 	if err != nil {
 		return nil, err
 	}
-
-	switch info := info.(type) {
-	case *dbase5.Header:
-		if len(p.fields) == 0 {
-			header := make([]string, len(info.Fields))
-			for _, f := range info.Fields {
-				header = append(header, f.Name())
-			}
-			return header, nil
-		} else {
-			return p.fields, nil
-		}
-	default:
-		return []string{}, fmt.Errorf("unsupported dBase version")
-	}
+	return fieldNames(info), nil
 }
 
 func (p TablePrinter) row(rec *Record) ([]string, error) {
-	info, err := p.scanner.Header()
+	// Add all fields if none specified, or just the specified fields
+	names, err := p.header()
 	if err != nil {
 		return nil, err
 	}
 
-	switch info := info.(type) {
-	case *dbase5.Header:
-		// Add all fields if none specified
-		if len(p.fields) == 0 {
-			row := make([]string, len(info.Fields))
-			for i, field := range info.Fields {
-				if f, ok := rec.Field(field.Name()); ok {
-					row[i] = fmt.Sprintf("%v", f.Value())
-				}
-			}
-			return row, nil
+	row := make([]string, len(names))
+	for i, name := range names {
+		if f, ok := rec.Field(name); ok {
+			row[i] = FormatValue(f.Value())
 		}
+	}
+	return row, nil
+}
+
+// fieldNames returns the names of the fields in the header, in order.
+func fieldNames(h Header) []string {
+	descs := h.FieldDescs()
+	names := make([]string, len(descs))
+	for i, f := range descs {
+		names[i] = f.Name()
+	}
+	return names
+}
 
-		// ...or just the specified fields
-		row := make([]string, len(p.fields))
-		for i, name := range p.fields {
-			if f, ok := rec.Field(name); ok {
-				row[i] = fmt.Sprintf("%v", f.Value())
-			}
+// FormatValue formats a field value for printing, where uninitialised values are blank.
+func FormatValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case *time.Time:
+		if v == nil {
+			return ""
 		}
-		return row, nil
-	default:
-		return []string{}, fmt.Errorf("unsupported dBase version")
+	case []byte:
+		return fmt.Sprintf("(%d bytes)", len(v))
 	}
+	return fmt.Sprintf("%v", v)
 }
@@ -1,12 +1,12 @@
 package dbf
 
-import "github.com/everystreet/go-shapefile/dbf/dbase5"
+import (
+	"github.com/everystreet/go-shapefile/dbf/field"
+)
 
-// Record wraps a dBase level-specific record.
+// Record wraps a record decoded from any dBase version.
 type Record struct {
-	rec interface {
-		Deleted() bool
-	}
+	rec *field.Record
 }
 
 // Field provides generic access to record fields of any type.
@@ -19,32 +19,20 @@ type Field interface {
 // Fields returns a list of all fields in the record.
 // The order of the fields is nondeterministic.
 func (r Record) Fields() []Field {
-	switch rec := r.rec.(type) {
-	case *dbase5.Record:
-		fields := make([]Field, len(rec.Fields))
-		i := 0
-		for _, f := range rec.Fields {
-			fields[i] = f
-			i++
-		}
-		return fields
-	default:
-		return nil
+	fields := make([]Field, 0, len(r.rec.Fields))
+	for _, f := range r.rec.Fields {
+		fields = append(fields, f)
 	}
+	return fields
 }
 
 // Field returns a field by name.
 func (r Record) Field(name string) (Field, bool) {
-	switch rec := r.rec.(type) {
-	case *dbase5.Record:
-		f, ok := rec.Fields[name]
-		if !ok {
-			return nil, false
-		}
-		return f.(Field), true
-	default:
+	f, ok := r.rec.Fields[name]
+	if !ok {
 		return nil, false
 	}
+	return f, true
 }
 
 // Deleted returns the state of the "deleted" marker.
@@ -1,11 +1,16 @@
 package dbf
 
 import (
+	"bufio"
 	"fmt"
 	"io"
 	"sync"
+	"time"
 
 	"github.com/everystreet/go-shapefile/dbf/dbase5"
+	"github.com/everystreet/go-shapefile/dbf/dbase7"
+	"github.com/everystreet/go-shapefile/dbf/field"
+	"github.com/everystreet/go-shapefile/dbf/vfp"
 )
 
 // Version is the dBase version or "level".
@@ -15,11 +20,17 @@ type Version uint
 const (
 	DBaseLevel5 Version = 3
 	DBaseLevel7 Version = 4
+
+	// FoxPro2 is FoxPro 2.x with a memo file, which has the same layout as dBase Level 5.
+	FoxPro2 Version = 5
+
+	// VisualFoxPro is Visual FoxPro, with or without autoincrement and varchar fields.
+	VisualFoxPro Version = 0x30
 )
 
 // Scanner parses a dbf file.
 type Scanner struct {
-	in io.Reader
+	in *bufio.Reader
 
 	versionOnce sync.Once
 	version     Version
@@ -37,15 +48,23 @@ type Scanner struct {
 
 // Header provides common information for all dbf version headers.
 type Header interface {
+	LastUpdate() time.Time
+	LanguageDriverID() uint8
+	HeaderLen() uint16
 	RecordLen() uint16
 	NumRecords() uint32
+	FieldDescs() []FieldDesc
 	FieldExists(string) bool
 }
 
+// FieldDesc provides common information for all dbf version field descriptors.
+// A type assertion can be used to access the version-specific field type.
+type FieldDesc = field.Desc
+
 // NewScanner creates a new Scanner for the supplied source.
 func NewScanner(r io.Reader) *Scanner {
 	return &Scanner{
-		in:        r,
+		in:        bufio.NewReaderSize(r, sampleSize),
 		recordsCh: make(chan *Record),
 	}
 }
@@ -62,8 +81,13 @@ func (s *Scanner) Version() (Version, error) {
 			return
 		}
 
-		// dBase version number is first 3 bits
-		s.version = Version(((buf[0]>>0)&1)<<0 | ((buf[0]>>1)&1)<<1 | ((buf[0]>>2)&1)<<2)
+		switch buf[0] {
+		case 0x30, 0x31, 0x32:
+			s.version = VisualFoxPro
+		default:
+			// dBase version number is first 3 bits
+			s.version = Version(((buf[0]>>0)&1)<<0 | ((buf[0]>>1)&1)<<1 | ((buf[0]>>2)&1)<<2)
+		}
 	})
 	return s.version, err
 }
@@ -78,10 +102,12 @@ func (s *Scanner) Header() (Header, error) {
 
 	s.headerOnce.Do(func() {
 		switch s.version {
-		case DBaseLevel5:
+		case DBaseLevel5, FoxPro2:
 			s.header, err = dbase5.DecodeHeader(s.in)
 		case DBaseLevel7:
-			err = fmt.Errorf("dBase Level 7 is not supported")
+			s.header, err = dbase7.DecodeHeader(s.in)
+		case VisualFoxPro:
+			s.header, err = vfp.DecodeHeader(s.in)
 		default:
 			err = fmt.Errorf("unsupported version")
 		}
@@ -149,22 +175,36 @@ func (s *Scanner) Err() error {
 }
 
 func (s *Scanner) decodeRecord(buf []byte, conf config) {
+	rec, err := s.decode(buf, conf)
+	if err != nil {
+		s.setErr(NewError(err, s.num))
+		return
+	}
+	s.recordsCh <- rec
+	s.num++
+}
+
+func (s *Scanner) decode(buf []byte, conf field.Config) (*Record, error) {
+	var rec *field.Record
+	var err error
+
 	switch s.version {
-	case DBaseLevel5:
-		rec, err := dbase5.DecodeRecord(buf, s.header.(*dbase5.Header), conf)
-		if err != nil {
-			s.setErr(NewError(err, s.num))
-			return
-		}
-		s.recordsCh <- &Record{
-			rec: rec,
-		}
-		s.num++
+	case DBaseLevel5, FoxPro2:
+		rec, err = dbase5.DecodeRecord(buf, s.header.(*dbase5.Header), conf)
 	case DBaseLevel7:
-		s.setErr(fmt.Errorf("dBase Level 7 is not supported"))
+		rec, err = dbase7.DecodeRecord(buf, s.header.(*dbase7.Header), conf)
+	case VisualFoxPro:
+		rec, err = vfp.DecodeRecord(buf, s.header.(*vfp.Header), conf)
 	default:
-		s.setErr(fmt.Errorf("unsupported version"))
+		err = fmt.Errorf("unsupported version")
+	}
+
+	if err != nil {
+		return nil, err
 	}
+	return &Record{
+		rec: rec,
+	}, nil
 }
 
 func (s *Scanner) record() ([]byte, error) {
@@ -1,12 +1,21 @@
 package dbf_test
 
 import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/everystreet/go-shapefile/dbf"
 	"github.com/everystreet/go-shapefile/dbf/dbase5"
+	"github.com/everystreet/go-shapefile/dbf/dbase7"
+	"github.com/everystreet/go-shapefile/dbf/memo"
+	"github.com/everystreet/go-shapefile/dbf/vfp"
 	"github.com/stretchr/testify/require"
 )
 
@@ -26,6 +35,14 @@ func TestScanner(t *testing.T) {
 	require.Equal(t, 1869, int(h.RecordLen()))
 	require.Equal(t, 171, int(h.NumRecords()))
 	require.Len(t, h.(*dbase5.Header).Fields, 94)
+	require.Equal(t, time.Date(2018, 5, 21, 0, 0, 0, 0, time.UTC), h.LastUpdate())
+	require.Equal(t, 3041, int(h.HeaderLen()))
+	require.Zero(t, h.LanguageDriverID())
+
+	featurecla := h.(*dbase5.Header).Fields[0]
+	require.Equal(t, "featurecla", featurecla.Name())
+	require.Equal(t, 19, int(featurecla.Length()))
+	require.Zero(t, featurecla.Decimals())
 
 	err = s.Scan()
 	require.NoError(t, err)
@@ -83,3 +100,424 @@ func TestScanner2(t *testing.T) {
 
 	require.NoError(t, r.Close())
 }
+
+func TestScannerLogical(t *testing.T) {
+	name, err := dbase5.NewFieldDesc("NAME", dbase5.CharacterType, 4, 0)
+	require.NoError(t, err)
+	capital, err := dbase5.NewFieldDesc("CAPITAL", dbase5.LogicalType, 1, 0)
+	require.NoError(t, err)
+
+	values := []string{"T", "t", "Y", "y", "F", "f", "N", "n", "?", " "}
+
+	buf := dbase5.NewHeader([]*dbase5.FieldDesc{name, capital}, uint32(len(values))).Encode()
+	for i, v := range values {
+		buf = append(buf, fmt.Sprintf(" %-4d%s", i, v)...)
+	}
+	buf = append(buf, 0x1A)
+
+	s := dbf.NewScanner(bytes.NewReader(buf))
+	require.NoError(t, s.Scan())
+
+	var got []interface{}
+	for {
+		rec := s.Record()
+		if rec == nil {
+			break
+		}
+
+		f, ok := rec.Field("CAPITAL")
+		require.True(t, ok)
+		got = append(got, f.Value())
+	}
+
+	require.NoError(t, s.Err())
+	require.Equal(t, []interface{}{true, true, true, true, false, false, false, false, nil, nil}, got)
+
+	var out bytes.Buffer
+	p, err := dbf.NewTablePrinter(dbf.NewScanner(bytes.NewReader(buf)))
+	require.NoError(t, err)
+	require.NoError(t, p.Print(&out))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, len(values)+1)
+	require.Equal(t, []string{"NAME", "CAPITAL"}, strings.Fields(lines[0]))
+	require.Equal(t, []string{"0", "true"}, strings.Fields(lines[1]))
+	require.Equal(t, []string{"8"}, strings.Fields(lines[9]))
+}
+
+func TestScannerHeader(t *testing.T) {
+	name, err := dbase5.NewFieldDesc("NAME", dbase5.CharacterType, 4, 0)
+	require.NoError(t, err)
+
+	t.Run("zero date", func(t *testing.T) {
+		buf := dbase5.NewHeader([]*dbase5.FieldDesc{name}, 0).Encode()
+		copy(buf[1:4], []byte{0, 0, 0})
+
+		h, err := dbf.NewScanner(bytes.NewReader(buf)).Header()
+		require.NoError(t, err)
+		require.True(t, h.LastUpdate().IsZero())
+	})
+
+	t.Run("invalid length", func(t *testing.T) {
+		buf := dbase5.NewHeader([]*dbase5.FieldDesc{name}, 0).Encode()
+		binary.LittleEndian.PutUint16(buf[8:10], 16)
+
+		_, err := dbf.NewScanner(bytes.NewReader(buf)).Header()
+		require.Error(t, err)
+	})
+}
+
+func TestScannerLevel7(t *testing.T) {
+	type desc struct {
+		name string
+		typ  byte
+		len  uint8
+	}
+
+	fields := []desc{
+		{"COUNTRY_NAME_LONG", 'C', 16},
+		{"ID", '+', 4},
+		{"POPULATION", 'I', 4},
+		{"AREA", 'O', 8},
+		{"UPDATED", '@', 8},
+		{"FOUNDED", 'D', 8},
+		{"ACTIVE", 'L', 1},
+	}
+
+	recLen := 1
+	for _, f := range fields {
+		recLen += int(f.len)
+	}
+
+	// Field properties follow the terminator, and are included in the header length
+	headerLen := 68 + len(fields)*48 + 1 + 4
+
+	buf := make([]byte, 68)
+	buf[0] = 0x04
+	binary.LittleEndian.PutUint32(buf[4:8], 2)
+	binary.LittleEndian.PutUint16(buf[8:10], uint16(headerLen))
+	binary.LittleEndian.PutUint16(buf[10:12], uint16(recLen))
+	buf[29] = 0x57
+	copy(buf[32:64], "DBWINUS0")
+
+	for _, f := range fields {
+		d := make([]byte, 48)
+		copy(d[0:32], f.name)
+		d[32] = f.typ
+		d[33] = f.len
+		buf = append(buf, d...)
+	}
+	buf = append(buf, 0x0D, 0, 0, 0, 0)
+
+	updated := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	for i, v := range []struct {
+		name    string
+		pop     int32
+		area    float64
+		updated []byte
+		founded string
+		active  string
+	}{
+		{"United Kingdom", 67886011, 242495.5, timestamp(updated), "18010101", "T"},
+		{"Nowhere", -12, -0.25, make([]byte, 8), "        ", "?"},
+	} {
+		buf = append(buf, ' ')
+		buf = append(buf, fmt.Sprintf("%-16s", v.name)...)
+		buf = append(buf, long(int32(i+1))...)
+		buf = append(buf, long(v.pop)...)
+		buf = append(buf, double(v.area)...)
+		buf = append(buf, v.updated...)
+		buf = append(buf, v.founded...)
+		buf = append(buf, v.active...)
+	}
+	buf = append(buf, 0x1A)
+
+	s := dbf.NewScanner(bytes.NewReader(buf))
+
+	v, err := s.Version()
+	require.NoError(t, err)
+	require.Equal(t, dbf.DBaseLevel7, v)
+
+	h, err := s.Header()
+	require.NoError(t, err)
+	require.IsType(t, &dbase7.Header{}, h)
+	require.Equal(t, "DBWINUS0", h.(*dbase7.Header).LanguageDriver)
+	require.Equal(t, 0x57, int(h.LanguageDriverID()))
+	require.Equal(t, headerLen, int(h.HeaderLen()))
+	require.Len(t, h.(*dbase7.Header).Fields, len(fields))
+	require.True(t, h.FieldExists("COUNTRY_NAME_LONG"))
+
+	require.NoError(t, s.Scan())
+
+	var got []map[string]interface{}
+	for {
+		rec := s.Record()
+		if rec == nil {
+			break
+		}
+
+		values := make(map[string]interface{})
+		for _, f := range rec.Fields() {
+			values[f.Name()] = f.Value()
+		}
+		got = append(got, values)
+	}
+	require.NoError(t, s.Err())
+
+	founded := time.Date(1801, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, []map[string]interface{}{
+		{
+			"COUNTRY_NAME_LONG": "United Kingdom",
+			"ID":                1.0,
+			"POPULATION":        67886011.0,
+			"AREA":              242495.5,
+			"UPDATED":           &updated,
+			"FOUNDED":           &founded,
+			"ACTIVE":            true,
+		},
+		{
+			"COUNTRY_NAME_LONG": "Nowhere",
+			"ID":                2.0,
+			"POPULATION":        -12.0,
+			"AREA":              -0.25,
+			"UPDATED":           nil,
+			"FOUNDED":           nil,
+			"ACTIVE":            nil,
+		},
+	}, got)
+
+	var out bytes.Buffer
+	p, err := dbf.NewTablePrinter(dbf.NewScanner(bytes.NewReader(buf)), "COUNTRY_NAME_LONG", "POPULATION")
+	require.NoError(t, err)
+	require.NoError(t, p.Print(&out))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 3)
+	require.Equal(t, []string{"Nowhere", "-12"}, strings.Fields(lines[2]))
+}
+
+func long(v int32) []byte {
+	buf := make([]byte, 4)
+	binary.BigEndian.PutUint32(buf, uint32(v)^0x80000000)
+	return buf
+}
+
+func double(v float64) []byte {
+	bits := math.Float64bits(v)
+	if v >= 0 {
+		bits |= 1 << 63
+	} else {
+		bits = ^bits
+	}
+
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, bits)
+	return buf
+}
+
+func timestamp(t time.Time) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint32(buf[0:4], uint32(t.Unix()/86400+2440588))
+	binary.BigEndian.PutUint32(buf[4:8], uint32(t.Unix()%86400*1000))
+	return buf
+}
+
+func TestScannerVisualFoxPro(t *testing.T) {
+	type desc struct {
+		name  string
+		typ   byte
+		len   uint8
+		flags byte
+	}
+
+	fields := []desc{
+		{"NAME", 'V', 10, 0x02},
+		{"POP", 'I', 4, 0},
+		{"GDP", 'Y', 8, 0},
+		{"AREA", 'B', 8, 0},
+		{"UPDATED", 'T', 8, 0},
+		{"NOTE", 'C', 5, 0x02},
+		{"DATA", 'Q', 4, 0},
+		{"NOTES", 'M', 4, 0},
+		{"_NullFlags", '0', 1, 0x05},
+	}
+
+	recLen := 1
+	for _, f := range fields {
+		recLen += int(f.len)
+	}
+
+	buf := make([]byte, 32)
+	buf[0] = 0x32
+	binary.LittleEndian.PutUint32(buf[4:8], 2)
+	binary.LittleEndian.PutUint16(buf[8:10], uint16(32+len(fields)*32+1+263))
+	binary.LittleEndian.PutUint16(buf[10:12], uint16(recLen))
+
+	for _, f := range fields {
+		d := make([]byte, 32)
+		copy(d[0:11], f.name)
+		d[11] = f.typ
+		d[16] = f.len
+		d[18] = f.flags
+		buf = append(buf, d...)
+	}
+	buf = append(buf, 0x0D)
+
+	backlink := make([]byte, 263)
+	copy(backlink, `..\countries.dbc`)
+	buf = append(buf, backlink...)
+
+	le := func(v interface{}) []byte {
+		var b bytes.Buffer
+		require.NoError(t, binary.Write(&b, binary.LittleEndian, v))
+		return b.Bytes()
+	}
+
+	updated := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	// NAME is shorter than the field and DATA is full length, so only the length bit for NAME is set
+	buf = append(buf, ' ')
+	buf = append(buf, "Wales\x00\x00\x00\x00\x05"...)
+	buf = append(buf, le(int32(3107494))...)
+	buf = append(buf, le(int64(12345678))...)
+	buf = append(buf, le(20779.5)...)
+	buf = append(buf, le([]uint32{uint32(updated.Unix()/86400 + 2440588), uint32(updated.Unix() % 86400 * 1000)})...)
+	buf = append(buf, "hello"...)
+	buf = append(buf, 1, 2, 3, 4)
+	buf = append(buf, le(uint32(8))...)
+	buf = append(buf, 0x01)
+
+	// NAME and NOTE are null, and DATA is shorter than the field
+	buf = append(buf, ' ')
+	buf = append(buf, make([]byte, 10)...)
+	buf = append(buf, le(int32(-1))...)
+	buf = append(buf, le(int64(-5000))...)
+	buf = append(buf, le(0.0)...)
+	buf = append(buf, make([]byte, 8)...)
+	buf = append(buf, "     "...)
+	buf = append(buf, 9, 9, 0, 2)
+	buf = append(buf, le(uint32(0))...)
+	buf = append(buf, 0x02|0x04|0x08)
+	buf = append(buf, 0x1A)
+
+	s := dbf.NewScanner(bytes.NewReader(buf))
+
+	v, err := s.Version()
+	require.NoError(t, err)
+	require.Equal(t, dbf.VisualFoxPro, v)
+
+	h, err := s.Header()
+	require.NoError(t, err)
+	require.IsType(t, &vfp.Header{}, h)
+	require.Equal(t, `..\countries.dbc`, h.(*vfp.Header).Backlink)
+	require.Len(t, h.(*vfp.Header).Fields, len(fields)-1)
+	require.False(t, h.FieldExists("_NullFlags"))
+
+	// memo blocks are referred to by 4 byte integer
+	fpt := make([]byte, 512+64)
+	binary.BigEndian.PutUint16(fpt[6:8], 64)
+	binary.BigEndian.PutUint32(fpt[512:516], 1)
+	binary.BigEndian.PutUint32(fpt[516:520], 5)
+	copy(fpt[520:], "notes")
+
+	m, err := memo.NewFPT(bytes.NewReader(fpt), int64(len(fpt)))
+	require.NoError(t, err)
+	require.NoError(t, s.Scan(dbf.MemoFile(m)))
+
+	var got []map[string]interface{}
+	for {
+		rec := s.Record()
+		if rec == nil {
+			break
+		}
+
+		values := make(map[string]interface{})
+		for _, f := range rec.Fields() {
+			values[f.Name()] = f.Value()
+		}
+		got = append(got, values)
+	}
+	require.NoError(t, s.Err())
+
+	require.Equal(t, []map[string]interface{}{
+		{
+			"NAME":    "Wales",
+			"POP":     3107494.0,
+			"GDP":     1234.5678,
+			"AREA":    20779.5,
+			"UPDATED": &updated,
+			"NOTE":    "hello",
+			"DATA":    []byte{1, 2, 3, 4},
+			"NOTES":   "notes",
+		},
+		{
+			"NAME":    nil,
+			"POP":     -1.0,
+			"GDP":     -0.5,
+			"AREA":    0.0,
+			"UPDATED": nil,
+			"NOTE":    nil,
+			"DATA":    []byte{9, 9},
+			"NOTES":   "",
+		},
+	}, got)
+}
+
+func TestScannerNullable(t *testing.T) {
+	name, err := dbase5.NewFieldDesc("NAME", dbase5.CharacterType, 4, 0)
+	require.NoError(t, err)
+	pop, err := dbase5.NewFieldDesc("POP", dbase5.NumericType, 8, 2)
+	require.NoError(t, err)
+	area, err := dbase5.NewFieldDesc("AREA", dbase5.FloatingPointType, 8, 2)
+	require.NoError(t, err)
+	founded, err := dbase5.NewFieldDesc("FOUNDED", dbase5.DateType, 8, 0)
+	require.NoError(t, err)
+
+	rows := []string{
+		"abcd    1,50    2.25" + "20200615",
+		"\x00\x00\x00\x00        ********" + "00000000",
+		"    ********   12,5x" + "        ",
+	}
+
+	buf := dbase5.NewHeader([]*dbase5.FieldDesc{name, pop, area, founded}, uint32(len(rows))).Encode()
+	for _, row := range rows {
+		buf = append(buf, ' ')
+		buf = append(buf, row...)
+	}
+	buf = append(buf, 0x1A)
+
+	scan := func(opts ...dbf.Option) ([][]interface{}, error) {
+		s := dbf.NewScanner(bytes.NewReader(buf))
+		require.NoError(t, s.Scan(opts...))
+
+		var out [][]interface{}
+		for {
+			rec := s.Record()
+			if rec == nil {
+				break
+			}
+
+			var values []interface{}
+			for _, name := range []string{"NAME", "POP", "AREA", "FOUNDED"} {
+				f, ok := rec.Field(name)
+				require.True(t, ok)
+				values = append(values, f.Value())
+			}
+			out = append(out, values)
+		}
+		return out, s.Err()
+	}
+
+	_, err = scan()
+	require.Error(t, err)
+
+	got, err := scan(dbf.InvalidAsNull(), dbf.DecimalSeparator(','))
+	require.NoError(t, err)
+
+	date := time.Date(2020, 6, 15, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, [][]interface{}{
+		{"abcd", 1.5, 2.25, &date},
+		{nil, nil, nil, nil},
+		{"", nil, nil, nil},
+	}, got)
+}
@@ -0,0 +1,97 @@
+package vfp
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// FieldType is the type of a field.
+type FieldType uint8
+
+// Field types for Visual FoxPro.
+const (
+	BlobType          FieldType = 'W'
+	CharacterType     FieldType = 'C'
+	CurrencyType      FieldType = 'Y'
+	DateTimeType      FieldType = 'T'
+	DateType          FieldType = 'D'
+	DoubleType        FieldType = 'B'
+	FloatingPointType FieldType = 'F'
+	GeneralType       FieldType = 'G'
+	IntegerType       FieldType = 'I'
+	LogicalType       FieldType = 'L'
+	MemoType          FieldType = 'M'
+	NullFlagsType     FieldType = '0'
+	NumericType       FieldType = 'N'
+	VarbinaryType     FieldType = 'Q'
+	VarcharType       FieldType = 'V'
+)
+
+// Field flags.
+const (
+	systemFlag   = 0x01
+	nullableFlag = 0x02
+)
+
+// FieldDesc represents a field descriptor consisting of a type, name and size in bytes.
+type FieldDesc struct {
+	Type FieldType
+
+	name     string
+	len      uint8
+	decimals uint8
+	flags    uint8
+	offset   int
+
+	// bits in the _NullFlags field, or -1 if the field has none
+	nullBit   int
+	lengthBit int
+}
+
+// DecodeFieldDesc parses a single field descriptor.
+func DecodeFieldDesc(buf []byte) (*FieldDesc, error) {
+	if len(buf) < 32 {
+		return nil, fmt.Errorf("expecting 32 bytes but have %d", len(buf))
+	}
+
+	name := bytes.Trim(buf[0:11], "\x00")
+	return &FieldDesc{
+		Type:      FieldType(buf[11]),
+		name:      string(name),
+		len:       buf[16],
+		decimals:  buf[17],
+		flags:     buf[18],
+		nullBit:   -1,
+		lengthBit: -1,
+	}, nil
+}
+
+// Name of the field.
+func (f FieldDesc) Name() string {
+	return f.name
+}
+
+// Length returns the size of the field in bytes.
+func (f FieldDesc) Length() uint8 {
+	return f.len
+}
+
+// Decimals returns the number of decimal places of a numeric field.
+func (f FieldDesc) Decimals() uint8 {
+	return f.decimals
+}
+
+// Nullable returns true if the field can hold null values.
+func (f FieldDesc) Nullable() bool {
+	return f.flags&nullableFlag != 0
+}
+
+// system returns true for hidden fields, such as _NullFlags.
+func (f FieldDesc) system() bool {
+	return f.flags&systemFlag != 0 || f.Type == NullFlagsType
+}
+
+// varLength returns true for fields that can hold values shorter than the field length.
+func (f FieldDesc) varLength() bool {
+	return f.Type == VarcharType || f.Type == VarbinaryType
+}
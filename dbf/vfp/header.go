@@ -0,0 +1,153 @@
+package vfp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/everystreet/go-shapefile/dbf/field"
+)
+
+// Header represents a Visual FoxPro file header.
+type Header struct {
+	// Fields excludes hidden fields, such as _NullFlags.
+	Fields []*FieldDesc
+
+	// Backlink is the path of the database container that the table belongs to, or empty for a free table.
+	Backlink string
+
+	lastUpdate time.Time
+	cpid       uint8
+	headerLen  uint16
+	recLen     uint16
+	numRecs    uint32
+	all        []*FieldDesc
+	nullFlags  *FieldDesc
+}
+
+// backlinkLen is the size of the backlink that follows the field descriptors.
+const backlinkLen = 263
+
+// DecodeHeader parses a Visual FoxPro file header.
+func DecodeHeader(r io.Reader) (*Header, error) {
+	// Read first 31 bytes after first byte
+	buf := make([]byte, 31)
+	if n, err := io.ReadFull(r, buf); err != nil {
+		return nil, fmt.Errorf("read %d bytes but expecting %d: %w", n, len(buf), err)
+	}
+
+	out := &Header{
+		lastUpdate: field.DecodeHeaderDate(buf[0:3]),
+		cpid:       buf[28],
+		headerLen:  binary.LittleEndian.Uint16(buf[7:9]),
+		recLen:     binary.LittleEndian.Uint16(buf[9:11]),
+		numRecs:    binary.LittleEndian.Uint32(buf[3:7]),
+	}
+
+	// Read remainder of header
+	headerLen := int(out.headerLen)
+	if headerLen <= len(buf)+1 {
+		return nil, fmt.Errorf("invalid header size %d bytes", headerLen)
+	}
+
+	buf = make([]byte, headerLen-len(buf)-1)
+	if n, err := io.ReadFull(r, buf); err != nil {
+		return nil, fmt.Errorf("read %d bytes but expecting %d: %w", n, len(buf), err)
+	}
+
+	pos, offset := 0, 1 // records start with the deletion flag
+	for i := 0; ; i++ {
+		if pos >= len(buf) {
+			return nil, fmt.Errorf("missing field descriptor terminator")
+		} else if buf[pos] == 0x0D {
+			break
+		}
+
+		f, err := DecodeFieldDesc(buf[pos:])
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode field %d: %w", i, err)
+		}
+		f.offset = offset
+		out.all = append(out.all, f)
+		pos += 32
+		offset += int(f.len)
+	}
+
+	if backlink := buf[pos+1:]; len(backlink) >= backlinkLen {
+		out.Backlink = string(bytes.Trim(backlink[:backlinkLen], "\x00"))
+	}
+
+	// Variable length fields have a bit in _NullFlags that is set if the value is shorter than the field,
+	// followed by a bit that is set if the value is null.
+	var bit int
+	for _, f := range out.all {
+		if f.system() {
+			if f.Type == NullFlagsType {
+				out.nullFlags = f
+			}
+			continue
+		}
+
+		if f.varLength() {
+			f.lengthBit = bit
+			bit++
+		}
+		if f.Nullable() {
+			f.nullBit = bit
+			bit++
+		}
+		out.Fields = append(out.Fields, f)
+	}
+
+	if bit > 0 && (out.nullFlags == nil || bit > int(out.nullFlags.len)*8) {
+		return nil, fmt.Errorf("missing _NullFlags field for %d flags", bit)
+	}
+
+	return out, nil
+}
+
+// LastUpdate returns the date that the file was last updated.
+func (h Header) LastUpdate() time.Time {
+	return h.lastUpdate
+}
+
+// LanguageDriverID returns the code page mark, which identifies the code page of character fields.
+// Zero means that it isn't set.
+func (h Header) LanguageDriverID() uint8 {
+	return h.cpid
+}
+
+// HeaderLen returns the size in bytes of the header, including the field descriptors and backlink.
+func (h Header) HeaderLen() uint16 {
+	return h.headerLen
+}
+
+// RecordLen returns the size in bytes of each record in the file.
+func (h Header) RecordLen() uint16 {
+	return h.recLen
+}
+
+// NumRecords returns the number of records in the file.
+func (h Header) NumRecords() uint32 {
+	return h.numRecs
+}
+
+// FieldDescs returns the descriptors of the visible fields, in order.
+func (h Header) FieldDescs() []field.Desc {
+	out := make([]field.Desc, len(h.Fields))
+	for i, f := range h.Fields {
+		out[i] = f
+	}
+	return out
+}
+
+func (h Header) FieldExists(name string) bool {
+	for _, field := range h.Fields {
+		if field.name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+package vfp
+
+import (
+	"fmt"
+
+	"github.com/everystreet/go-shapefile/dbf/field"
+)
+
+// DecodeRecord decodes a Visual FoxPro single record.
+// Fields that are marked as null in the hidden _NullFlags field are decoded as field.Null.
+func DecodeRecord(buf []byte, header *Header, conf field.Config) (*field.Record, error) {
+	if len(buf) < int(header.recLen) {
+		return nil, fmt.Errorf("expecting %d bytes but have %d", header.recLen, len(buf))
+	}
+
+	rec, err := field.NewRecord(buf[0], len(header.Fields))
+	if err != nil {
+		return nil, err
+	}
+
+	var flags []byte
+	if nf := header.nullFlags; nf != nil {
+		if len(buf) < nf.offset+int(nf.len) {
+			return nil, fmt.Errorf("expecting _NullFlags at %d bytes but have %d", nf.offset, len(buf))
+		}
+		flags = buf[nf.offset : nf.offset+int(nf.len)]
+	}
+
+	isSet := func(bit int) bool {
+		return bit >= 0 && flags[bit/8]&(1<<(bit%8)) != 0
+	}
+
+	for i, desc := range header.Fields {
+		start, end := desc.offset, desc.offset+int(desc.len)
+		if len(buf) < end {
+			return nil, field.DecodeError(desc.name, i,
+				fmt.Errorf("expecting %d bytes but have %d", desc.len, len(buf)-start))
+		}
+
+		// filter out unwanted fields
+		if !field.Wanted(desc.name, conf.FilteredFields()) {
+			continue
+		} else if isSet(desc.nullBit) {
+			rec.Fields[desc.name] = field.NewNull(desc.name)
+			continue
+		}
+
+		val := buf[start:end]
+		if isSet(desc.lengthBit) {
+			// the length of a shorter value is stored in the last byte
+			if n := int(val[len(val)-1]); n < len(val) {
+				val = val[:n]
+			} else {
+				return nil, field.DecodeError(desc.name, i, fmt.Errorf("invalid length %d", n))
+			}
+		}
+
+		var f field.Value
+		var err error
+
+		switch desc.Type {
+		case BlobType, GeneralType:
+			f, err = field.DecodeFoxProBinaryMemo(val, desc.name, conf.MemoFile())
+		case CharacterType, VarcharType:
+			f, err = field.DecodeCharacter(val, desc.name, conf.CharacterDecoder())
+		case CurrencyType:
+			f, err = field.DecodeCurrency(val, desc.name)
+		case DateTimeType:
+			f, err = field.DecodeFoxProDateTime(val, desc.name)
+		case DateType:
+			f, err = field.DecodeDate(val, desc.name, conf.ParseOptions())
+		case DoubleType:
+			f, err = field.DecodeFoxProDouble(val, desc.name)
+		case FloatingPointType:
+			f, err = field.DecodeFloatingPoint(val, desc.name, conf.ParseOptions())
+		case IntegerType:
+			f, err = field.DecodeFoxProInteger(val, desc.name)
+		case LogicalType:
+			f, err = field.DecodeLogical(val, desc.name)
+		case MemoType:
+			f, err = field.DecodeFoxProMemo(val, desc.name, conf.MemoFile(), conf.CharacterDecoder())
+		case NumericType:
+			f, err = field.DecodeNumeric(val, desc.name, conf.ParseOptions())
+		case VarbinaryType:
+			f, err = field.DecodeBinary(val, desc.name)
+		default:
+			return nil, field.DecodeError(desc.name, i,
+				fmt.Errorf("unsupported field type '%c'", desc.Type))
+		}
+
+		if err != nil {
+			return nil, field.DecodeError(desc.name, i, err)
+		}
+		rec.Fields[f.Name()] = f
+	}
+
+	return rec, nil
+}
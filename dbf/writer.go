@@ -0,0 +1,68 @@
+package dbf
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/everystreet/go-shapefile/dbf/dbase5"
+	"golang.org/x/text/encoding"
+)
+
+// Writer encodes records to a dBase level 5 file.
+type Writer struct {
+	out     io.WriteSeeker
+	header  *dbase5.Header
+	encoder *encoding.Encoder
+	num     uint32
+}
+
+// NewWriter creates a Writer for records with the specified fields.
+// Character values are written as-is, unless an encoder is supplied.
+// The header is written immediately, and rewritten with the final number of records by Close.
+func NewWriter(w io.WriteSeeker, fields []*dbase5.FieldDesc, encoder *encoding.Encoder) (*Writer, error) {
+	if encoder == nil {
+		encoder = encoding.Nop.NewEncoder()
+	}
+
+	header := dbase5.NewHeader(fields, 0)
+	if _, err := w.Write(header.Encode()); err != nil {
+		return nil, fmt.Errorf("failed to write header: %w", err)
+	}
+
+	return &Writer{
+		out:     w,
+		header:  header,
+		encoder: encoder,
+	}, nil
+}
+
+// Write encodes a single record, where values are in the same order as the fields.
+// See dbase5.EncodeRecord for the supported value types.
+func (w *Writer) Write(values ...interface{}) error {
+	buf, err := dbase5.EncodeRecord(values, w.header, w.encoder)
+	if err != nil {
+		return fmt.Errorf("failed to encode record %d: %w", w.num, err)
+	}
+
+	if _, err := w.out.Write(buf); err != nil {
+		return fmt.Errorf("failed to write record %d: %w", w.num, err)
+	}
+	w.num++
+	return nil
+}
+
+// Close writes the file terminator and updates the header. It does not close the underlying writer.
+func (w *Writer) Close() error {
+	if _, err := w.out.Write([]byte{0x1A}); err != nil {
+		return fmt.Errorf("failed to write file terminator: %w", err)
+	}
+
+	if _, err := w.out.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	} else if _, err := w.out.Write(dbase5.NewHeader(w.header.Fields, w.num).Encode()); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+
+	_, err := w.out.Seek(0, io.SeekEnd)
+	return err
+}
@@ -0,0 +1,68 @@
+package dbf_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/everystreet/go-shapefile/dbf"
+	"github.com/everystreet/go-shapefile/dbf/dbase5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriterNull(t *testing.T) {
+	name, err := dbase5.NewFieldDesc("NAME", dbase5.CharacterType, 4, 0)
+	require.NoError(t, err)
+	pop, err := dbase5.NewFieldDesc("POP", dbase5.NumericType, 8, 2)
+	require.NoError(t, err)
+	capital, err := dbase5.NewFieldDesc("CAPITAL", dbase5.LogicalType, 1, 0)
+	require.NoError(t, err)
+
+	_, err = dbase5.NewFieldDesc("FLAG", dbase5.LogicalType, 2, 0)
+	require.Error(t, err)
+
+	f, err := os.Create(filepath.Join(t.TempDir(), "test.dbf"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	w, err := dbf.NewWriter(f, []*dbase5.FieldDesc{name, pop, capital}, nil)
+	require.NoError(t, err)
+	require.NoError(t, w.Write("abc", 1.5, true))
+	require.NoError(t, w.Write("", nil, false))
+	require.NoError(t, w.Write(nil, nil, nil))
+	require.NoError(t, w.Close())
+
+	_, err = f.Seek(0, 0)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(f)
+	require.NoError(t, err)
+
+	s := dbf.NewScanner(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, s.Scan())
+
+	var got [][]interface{}
+	for {
+		rec := s.Record()
+		if rec == nil {
+			break
+		}
+
+		var values []interface{}
+		for _, name := range []string{"NAME", "POP", "CAPITAL"} {
+			f, ok := rec.Field(name)
+			require.True(t, ok)
+			values = append(values, f.Value())
+		}
+		got = append(got, values)
+	}
+
+	require.NoError(t, s.Err())
+	require.Equal(t, [][]interface{}{
+		{"abc", 1.5, true},
+		{"", nil, false},
+		{nil, nil, nil},
+	}, got)
+}
@@ -0,0 +1,100 @@
+package shapefile
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// File wraps Scanner, providing access to a shapefile stored as a set of files.
+// It must be closed once it's no longer needed.
+type File struct {
+	*Scanner
+	Layer Layer
+
+	files io.Closer
+}
+
+// Open opens the named shapefile from the supplied file system.
+// The name may refer to the .shp file, or to the layer name without any extension.
+// Sibling .dbf, .shx, .cpg, .prj, .dbt and .fpt files are found regardless of the case of their extensions,
+// the character decoder specified by a .cpg file is applied automatically, and memo fields are read from
+// a .dbt or .fpt file.
+func Open(fsys fs.FS, name string, opts ...Option) (*File, error) {
+	name = path.Clean(name)
+	if _, ok := layerExts[strings.ToLower(path.Ext(name))]; ok {
+		name = strings.TrimSuffix(name, path.Ext(name))
+	}
+
+	dir := path.Dir(name)
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory '%s': %w", dir, err)
+	}
+
+	a := fsArchive{
+		fsys:  fsys,
+		files: make([]string, 0, len(entries)),
+	}
+	for _, e := range entries {
+		if !e.IsDir() {
+			a.files = append(a.files, path.Join(dir, e.Name()))
+		}
+	}
+
+	var layer *Layer
+	for _, l := range findLayers(a.names()) {
+		if l.Name == name {
+			layer = &l
+			break
+		}
+	}
+
+	if layer == nil {
+		return nil, fmt.Errorf("missing .shp file for '%s'", name)
+	}
+
+	scanner, files, err := openLayer(a, *layer, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &File{
+		Scanner: scanner,
+		Layer:   *layer,
+		files:   files,
+	}, nil
+}
+
+// OpenPath opens the shapefile at the specified path on disk.
+// See Open for details of how the path is interpreted.
+func OpenPath(name string, opts ...Option) (*File, error) {
+	dir, base := filepath.Split(name)
+	if dir == "" {
+		dir = "."
+	}
+	return Open(os.DirFS(dir), base, opts...)
+}
+
+// Close closes all of the underlying files.
+func (f *File) Close() error {
+	return f.files.Close()
+}
+
+// fsArchive provides access to files in a single directory of a file system.
+type fsArchive struct {
+	fsys  fs.FS
+	files []string
+}
+
+func (a fsArchive) names() []string {
+	return a.files
+}
+
+func (a fsArchive) open(name string) (io.ReadCloser, error) {
+	return a.fsys.Open(name)
+}
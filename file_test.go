@@ -0,0 +1,131 @@
+package shapefile_test
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/everystreet/go-shapefile"
+	"github.com/everystreet/go-shapefile/dbf/dbase5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpen(t *testing.T) {
+	shp, err := os.ReadFile(filepath.Join("testdata", "ne_110m_admin_0_sovereignty.shp"))
+	require.NoError(t, err)
+
+	dbf, err := os.ReadFile(filepath.Join("testdata", "ne_110m_admin_0_sovereignty.dbf"))
+	require.NoError(t, err)
+
+	fsys := fstest.MapFS{
+		"data/countries.SHP": {Data: shp},
+		"data/countries.DBF": {Data: dbf},
+		"data/countries.Cpg": {Data: []byte("UTF-8\n")},
+		"data/other.shp":     {Data: shp},
+	}
+
+	for _, name := range []string{"data/countries.SHP", "data/countries", "./data/countries"} {
+		t.Run(name, func(t *testing.T) {
+			f, err := shapefile.Open(fsys, name)
+			require.NoError(t, err)
+
+			require.Equal(t, "data/countries", f.Layer.Name)
+			cpg, ok := f.Layer.File(".cpg")
+			require.True(t, ok)
+			require.Equal(t, "data/countries.Cpg", cpg)
+
+			info, err := f.Info()
+			require.NoError(t, err)
+
+			err = f.Scan()
+			require.NoError(t, err)
+
+			var num uint32
+			for f.Record() != nil {
+				num++
+			}
+
+			require.NoError(t, f.Err())
+			require.Equal(t, info.NumRecords, num)
+			require.NoError(t, f.Close())
+		})
+	}
+
+	t.Run("missing dbf", func(t *testing.T) {
+		_, err := shapefile.Open(fsys, "data/other.shp")
+		require.EqualError(t, err, "missing .dbf file")
+	})
+
+	t.Run("missing shp", func(t *testing.T) {
+		_, err := shapefile.Open(fsys, "data/missing.shp")
+		require.EqualError(t, err, "missing .shp file for 'data/missing'")
+	})
+}
+
+func TestOpenPath(t *testing.T) {
+	f, err := shapefile.OpenPath(filepath.Join("testdata", "ne_110m_admin_0_sovereignty.shp"))
+	require.NoError(t, err)
+
+	info, err := f.Info()
+	require.NoError(t, err)
+	require.Equal(t, 171, int(info.NumRecords))
+
+	require.NoError(t, f.Close())
+}
+
+func TestOpenMemo(t *testing.T) {
+	shp, err := os.ReadFile(filepath.Join("testdata", "ne_110m_admin_0_sovereignty.shp"))
+	require.NoError(t, err)
+
+	const numRecords = 171
+
+	// FoxPro 2 dbf with a memo field that refers to blocks by number
+	desc, err := dbase5.NewFieldDesc("NOTES", dbase5.MemoType, 10, 0)
+	require.NoError(t, err)
+
+	dbf := dbase5.NewHeader([]*dbase5.FieldDesc{desc}, numRecords).Encode()
+	dbf[0] = 0xF5
+	for i := 0; i < numRecords; i++ {
+		block := "          "
+		if i%2 == 0 {
+			block = "         8"
+		}
+		dbf = append(append(dbf, ' '), block...)
+	}
+	dbf = append(dbf, 0x1A)
+
+	fpt := make([]byte, 512+64)
+	binary.BigEndian.PutUint16(fpt[6:8], 64)
+	binary.BigEndian.PutUint32(fpt[512:516], 1)
+	binary.BigEndian.PutUint32(fpt[516:520], 5)
+	copy(fpt[520:], "notes")
+
+	f, err := shapefile.Open(fstest.MapFS{
+		"countries.shp": {Data: shp},
+		"countries.dbf": {Data: dbf},
+		"countries.FPT": {Data: fpt},
+	}, "countries")
+	require.NoError(t, err)
+	defer f.Close()
+
+	require.NoError(t, f.Scan())
+
+	var values []interface{}
+	for {
+		rec := f.Record()
+		if rec == nil {
+			break
+		}
+
+		field, ok := rec.Field("NOTES")
+		require.True(t, ok)
+		values = append(values, field.Value())
+	}
+
+	require.NoError(t, f.Err())
+	require.Len(t, values, numRecords)
+	require.Equal(t, "notes", values[0])
+	require.Equal(t, "", values[1])
+}
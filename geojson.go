@@ -0,0 +1,94 @@
+package shapefile
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// WriteFeatureCollection writes every record from the scanner to w as a GeoJSON FeatureCollection,
+// with a bbox taken from the shapefile header. Features are written one at a time as they're scanned,
+// so memory usage doesn't depend on the number of records.
+func WriteFeatureCollection(w io.Writer, s Scannable, opts ...GeoJSONOption) error {
+	if err := s.Scan(); err != nil {
+		return err
+	}
+
+	info, err := s.Info()
+	if err != nil {
+		return err
+	}
+
+	bbox, err := json.Marshal([]float64{
+		info.BoundingBox.MinX,
+		info.BoundingBox.MinY,
+		info.BoundingBox.MaxX,
+		info.BoundingBox.MaxY,
+	})
+	if err != nil {
+		return err
+	}
+
+	out := bufio.NewWriter(w)
+	fmt.Fprintf(out, `{"type":"FeatureCollection","bbox":%s,"features":[`, bbox)
+
+	var n int
+	if err := writeFeatures(s, opts, func(feature []byte) error {
+		if n > 0 {
+			if err := out.WriteByte(','); err != nil {
+				return err
+			}
+		}
+		n++
+
+		_, err := out.Write(feature)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	if _, err := out.WriteString("]}\n"); err != nil {
+		return err
+	}
+	return out.Flush()
+}
+
+// WriteGeoJSONSeq writes every record from the scanner to w as a GeoJSON Text Sequence (RFC 8142),
+// where each feature is preceded by a record separator and followed by a line feed.
+// Features are written one at a time as they're scanned.
+func WriteGeoJSONSeq(w io.Writer, s Scannable, opts ...GeoJSONOption) error {
+	if err := s.Scan(); err != nil {
+		return err
+	}
+
+	out := bufio.NewWriter(w)
+	if err := writeFeatures(s, opts, func(feature []byte) error {
+		if err := out.WriteByte(0x1E); err != nil {
+			return err
+		} else if _, err := out.Write(feature); err != nil {
+			return err
+		}
+		return out.WriteByte('\n')
+	}); err != nil {
+		return err
+	}
+	return out.Flush()
+}
+
+func writeFeatures(s Scannable, opts []GeoJSONOption, fn func([]byte) error) error {
+	for {
+		rec := s.Record()
+		if rec == nil {
+			break
+		}
+
+		feature, err := json.Marshal(rec.GeoJSONFeature(opts...))
+		if err != nil {
+			return fmt.Errorf("failed to encode record %d: %w", rec.Shape.RecordNumber(), err)
+		} else if err := fn(feature); err != nil {
+			return err
+		}
+	}
+	return s.Err()
+}
@@ -0,0 +1,74 @@
+package shapefile_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/everystreet/go-shapefile"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteFeatureCollection(t *testing.T) {
+	f, err := shapefile.OpenPath(filepath.Join("testdata", "ne_110m_admin_0_sovereignty.shp"),
+		shapefile.FilterFields("NAME"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	var buf bytes.Buffer
+	require.NoError(t, shapefile.WriteFeatureCollection(&buf, f,
+		shapefile.RenameProperties(map[string]string{"NAME": "name"})))
+
+	var collection struct {
+		Type     string    `json:"type"`
+		BBox     []float64 `json:"bbox"`
+		Features []struct {
+			Type       string                 `json:"type"`
+			Properties map[string]interface{} `json:"properties"`
+		} `json:"features"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &collection))
+
+	info, err := f.Info()
+	require.NoError(t, err)
+
+	require.Equal(t, "FeatureCollection", collection.Type)
+	require.Equal(t, []float64{
+		info.BoundingBox.MinX,
+		info.BoundingBox.MinY,
+		info.BoundingBox.MaxX,
+		info.BoundingBox.MaxY,
+	}, collection.BBox)
+	require.Len(t, collection.Features, int(info.NumRecords))
+
+	for _, feature := range collection.Features {
+		require.Equal(t, "Feature", feature.Type)
+		require.Len(t, feature.Properties, 1)
+		require.Contains(t, feature.Properties, "name")
+	}
+}
+
+func TestWriteGeoJSONSeq(t *testing.T) {
+	f, err := shapefile.OpenPath(filepath.Join("testdata", "ne_110m_admin_0_sovereignty.shp"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	var buf bytes.Buffer
+	require.NoError(t, shapefile.WriteGeoJSONSeq(&buf, f))
+
+	info, err := f.Info()
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, int(info.NumRecords))
+
+	for _, line := range lines {
+		require.True(t, strings.HasPrefix(line, "\x1e"))
+
+		var feature map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line[1:]), &feature))
+		require.Equal(t, "Feature", feature["type"])
+	}
+}
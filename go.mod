@@ -1,6 +1,6 @@
 module github.com/everystreet/go-shapefile
 
-go 1.13
+go 1.16
 
 require (
 	github.com/ajstarks/svgo v0.0.0-20210406150507-75cfd577ce75
@@ -11,6 +11,7 @@ require (
 	github.com/golang/geo v0.0.0-20210211234256-740aa86cb551
 	github.com/kr/text v0.2.0 // indirect
 	github.com/mattn/go-runewidth v0.0.12 // indirect
+	github.com/mattn/go-sqlite3 v1.14.0
 	github.com/olekukonko/tablewriter v0.0.5
 	github.com/pkg/errors v0.9.1 // indirect
 	github.com/rivo/uniseg v0.2.0 // indirect
@@ -0,0 +1,588 @@
+package shapefile
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/everystreet/go-shapefile/dbf"
+	"github.com/everystreet/go-shapefile/dbf/dbase5"
+	"github.com/everystreet/go-shapefile/dbf/field"
+	"github.com/everystreet/go-shapefile/shp"
+)
+
+// Limits of the dbf fields created by ImportGeoJSON.
+const (
+	maxFieldNameLen = 10
+	maxCharacterLen = 254
+	maxNumericLen   = 19
+	maxDecimals     = 15
+)
+
+// ImportReport describes the shapefiles written by ImportGeoJSON.
+type ImportReport struct {
+	// Layers are the paths of the shapefiles that were written, without extensions.
+	Layers []string
+
+	// Warnings describe features and properties that couldn't be written exactly as they appear in the GeoJSON.
+	Warnings []ImportWarning
+}
+
+// ImportWarning describes a feature or property that was skipped, truncated or coerced to a different type.
+type ImportWarning struct {
+	// Feature is the index of the feature in the collection, or -1 if the warning applies to all features.
+	Feature int
+
+	// Property is the name of the property, or empty if the warning applies to the whole feature.
+	Property string
+
+	Message string
+}
+
+func (w ImportWarning) String() string {
+	switch {
+	case w.Feature < 0:
+		return fmt.Sprintf("property '%s': %s", w.Property, w.Message)
+	case w.Property == "":
+		return fmt.Sprintf("feature %d: %s", w.Feature, w.Message)
+	default:
+		return fmt.Sprintf("feature %d, property '%s': %s", w.Feature, w.Property, w.Message)
+	}
+}
+
+// ImportGeoJSON converts a GeoJSON FeatureCollection into one or more shapefiles in dir,
+// consisting of .shp, .shx, .dbf and .cpg files.
+//
+// If every feature has the same kind of geometry, a single shapefile called name is written.
+// Otherwise, a shapefile is written for each kind, named with a "_points", "_lines" or "_polygons" suffix.
+// Points are written as Point shapes, LineStrings and MultiLineStrings are written as Polylines, and Polygons and MultiPolygons as Polygons.
+// Features with no geometry, a MultiPoint or a GeometryCollection are skipped.
+//
+// The dbf schema is inferred from the feature properties: booleans become Logical fields,
+// numbers become Numeric fields wide enough for every value, and everything else becomes a Character field.
+// Character values are written as UTF-8.
+func ImportGeoJSON(r io.Reader, dir, name string) (*ImportReport, error) {
+	var collection struct {
+		Type     string            `json:"type"`
+		Features []json.RawMessage `json:"features"`
+	}
+	if err := json.NewDecoder(r).Decode(&collection); err != nil {
+		return nil, fmt.Errorf("failed to parse GeoJSON: %w", err)
+	} else if collection.Type != "FeatureCollection" {
+		return nil, fmt.Errorf("type is '%s', expecting 'FeatureCollection'", collection.Type)
+	}
+
+	report := &ImportReport{}
+	warn := func(feature int, property, format string, args ...interface{}) {
+		report.Warnings = append(report.Warnings, ImportWarning{
+			Feature:  feature,
+			Property: property,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	byType := make(map[shp.ShapeType][]importFeature)
+	for i, raw := range collection.Features {
+		feature, err := parseImportFeature(raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse feature %d: %w", i, err)
+		}
+		feature.index = i
+
+		if feature.shape, err = feature.makeShape(); err != nil {
+			warn(i, "", "skipped: %v", err)
+			continue
+		}
+		byType[feature.shape.Type()] = append(byType[feature.shape.Type()], feature)
+	}
+
+	if len(byType) == 0 {
+		return nil, fmt.Errorf("no features with supported geometries")
+	}
+
+	for _, typ := range []shp.ShapeType{shp.PointType, shp.PolylineType, shp.PolygonType} {
+		features, ok := byType[typ]
+		if !ok {
+			continue
+		}
+
+		layer := filepath.Join(dir, name)
+		if len(byType) > 1 {
+			layer += map[shp.ShapeType]string{
+				shp.PointType:    "_points",
+				shp.PolylineType: "_lines",
+				shp.PolygonType:  "_polygons",
+			}[typ]
+		}
+
+		if err := writeImportLayer(layer, typ, features, warn); err != nil {
+			return nil, err
+		}
+		report.Layers = append(report.Layers, layer)
+	}
+	return report, nil
+}
+
+type importFeature struct {
+	index      int
+	geometry   importGeometry
+	properties []importProperty
+	shape      shp.Shape
+}
+
+type importGeometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+type importProperty struct {
+	name  string
+	value interface{}
+}
+
+func parseImportFeature(data []byte) (importFeature, error) {
+	var raw struct {
+		Type       string          `json:"type"`
+		Geometry   *importGeometry `json:"geometry"`
+		Properties json.RawMessage `json:"properties"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return importFeature{}, err
+	} else if raw.Type != "Feature" {
+		return importFeature{}, fmt.Errorf("type is '%s', expecting 'Feature'", raw.Type)
+	}
+
+	var out importFeature
+	if raw.Geometry != nil {
+		out.geometry = *raw.Geometry
+	}
+
+	var err error
+	out.properties, err = parseImportProperties(raw.Properties)
+	return out, err
+}
+
+// parseImportProperties decodes a properties object, retaining the order of its members.
+// Numbers are decoded as json.Number.
+func parseImportProperties(data []byte) ([]importProperty, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if tok, err := dec.Token(); err != nil {
+		return nil, err
+	} else if tok != json.Delim('{') {
+		return nil, fmt.Errorf("properties must be an object")
+	}
+
+	var props []importProperty
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		var value interface{}
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+		props = append(props, importProperty{
+			name:  tok.(string),
+			value: value,
+		})
+	}
+	return props, nil
+}
+
+// makeShape converts the geometry to a shape.
+func (f importFeature) makeShape() (shp.Shape, error) {
+	switch f.geometry.Type {
+	case "":
+		return nil, fmt.Errorf("missing geometry")
+	case "Point":
+		var pos []float64
+		if err := json.Unmarshal(f.geometry.Coordinates, &pos); err != nil {
+			return nil, err
+		}
+
+		p, err := importPoint(pos)
+		if err != nil {
+			return nil, err
+		}
+		return p, nil
+	case "LineString", "MultiLineString":
+		var lines [][][]float64
+		if f.geometry.Type == "LineString" {
+			lines = make([][][]float64, 1)
+			if err := json.Unmarshal(f.geometry.Coordinates, &lines[0]); err != nil {
+				return nil, err
+			}
+		} else if err := json.Unmarshal(f.geometry.Coordinates, &lines); err != nil {
+			return nil, err
+		}
+
+		parts, err := importParts(lines)
+		if err != nil {
+			return nil, err
+		}
+		return shp.MakePolyline(parts...), nil
+	case "Polygon", "MultiPolygon":
+		var polygons [][][][]float64
+		if f.geometry.Type == "Polygon" {
+			polygons = make([][][][]float64, 1)
+			if err := json.Unmarshal(f.geometry.Coordinates, &polygons[0]); err != nil {
+				return nil, err
+			}
+		} else if err := json.Unmarshal(f.geometry.Coordinates, &polygons); err != nil {
+			return nil, err
+		}
+
+		rings := make([][]shp.Part, len(polygons))
+		for i, polygon := range polygons {
+			var err error
+			if rings[i], err = importParts(polygon); err != nil {
+				return nil, err
+			}
+		}
+
+		p, err := shp.MakePolygon(rings...)
+		if err != nil {
+			return nil, err
+		}
+		return p, nil
+	default:
+		return nil, fmt.Errorf("unsupported geometry type '%s'", f.geometry.Type)
+	}
+}
+
+func importParts(lines [][][]float64) ([]shp.Part, error) {
+	parts := make([]shp.Part, len(lines))
+	for i, line := range lines {
+		var err error
+		if parts[i], err = importPart(line); err != nil {
+			return nil, err
+		}
+	}
+	return parts, nil
+}
+
+func importPart(positions [][]float64) (shp.Part, error) {
+	part := make(shp.Part, len(positions))
+	for i, pos := range positions {
+		var err error
+		if part[i], err = importPoint(pos); err != nil {
+			return nil, err
+		}
+	}
+	return part, nil
+}
+
+func importPoint(pos []float64) (shp.Point, error) {
+	if len(pos) < 2 {
+		return shp.Point{}, fmt.Errorf("position has %d coordinates", len(pos))
+	}
+	return shp.MakePoint(pos[0], pos[1]), nil
+}
+
+// importField describes a dbf field inferred from the values of a property.
+type importField struct {
+	property string
+	desc     *dbase5.FieldDesc
+	length   int
+
+	strings, numbers, bools, others int
+
+	maxLen   int // longest value, in bytes, when encoded as a string
+	intLen   int // longest integer part of a number, including the sign
+	decimals int
+}
+
+func (f *importField) add(value interface{}) {
+	switch v := value.(type) {
+	case nil:
+		return
+	case string:
+		f.strings++
+	case json.Number:
+		f.numbers++
+
+		n, err := importNumber(v)
+		if err != nil {
+			f.others++
+			break
+		}
+
+		var s string
+		switch n := n.(type) {
+		case int64:
+			s = strconv.FormatInt(n, 10)
+		case float64:
+			s = strconv.FormatFloat(n, 'f', -1, 64)
+		}
+
+		if i := strings.IndexByte(s, '.'); i >= 0 {
+			f.intLen = maxInt(f.intLen, i)
+			f.decimals = maxInt(f.decimals, len(s)-i-1)
+		} else {
+			f.intLen = maxInt(f.intLen, len(s))
+		}
+	case bool:
+		f.bools++
+	default:
+		f.others++
+	}
+	f.maxLen = maxInt(f.maxLen, len(importString(value)))
+}
+
+// fieldDesc chooses the field type and size.
+func (f *importField) fieldDesc(name string, warn func(string, ...interface{})) (*dbase5.FieldDesc, error) {
+	switch {
+	case f.strings == 0 && f.others == 0 && f.numbers == 0 && f.bools > 0:
+		return dbase5.NewFieldDesc(name, dbase5.LogicalType, 1, 0)
+	case f.strings == 0 && f.others == 0 && f.bools == 0 && f.numbers > 0:
+		decimals := minInt(f.decimals, maxDecimals)
+		if decimals > 0 && f.intLen+1+decimals > maxNumericLen {
+			decimals = maxInt(0, maxNumericLen-f.intLen-1)
+		}
+		if decimals < f.decimals {
+			warn("decimal places reduced from %d to %d", f.decimals, decimals)
+		}
+
+		f.decimals = decimals
+		f.length = f.intLen
+		if decimals > 0 {
+			f.length += 1 + decimals
+		}
+		f.length = minInt(f.length, maxNumericLen)
+		return dbase5.NewFieldDesc(name, dbase5.NumericType, uint8(f.length), uint8(decimals))
+	default:
+		if f.numbers+f.bools+f.others > 0 {
+			warn("mixed or nested values written as strings")
+		}
+		f.length = minInt(maxInt(f.maxLen, 1), maxCharacterLen)
+		return dbase5.NewFieldDesc(name, dbase5.CharacterType, uint8(f.length), 0)
+	}
+}
+
+// value converts a property value to one that can be encoded as the field type.
+func (f *importField) value(value interface{}, warn func(string, ...interface{})) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	switch f.desc.Type {
+	case dbase5.LogicalType:
+		return value, nil
+	case dbase5.NumericType:
+		n, err := importNumber(value.(json.Number))
+		if err != nil {
+			return nil, err
+		}
+
+		switch v := n.(type) {
+		case int64:
+			_, err = field.EncodeInteger(v, f.length, f.decimals)
+		case float64:
+			_, err = field.EncodeNumeric(v, f.length, f.decimals)
+		}
+		if err != nil {
+			warn("value %s is too wide for the field and was written as null", value)
+			return nil, nil
+		}
+		return n, nil
+	default:
+		s := importString(value)
+		if len(s) > maxCharacterLen {
+			warn("value truncated from %d to %d bytes", len(s), maxCharacterLen)
+			s = truncateUTF8(s, maxCharacterLen)
+		}
+		return s, nil
+	}
+}
+
+// importNumber converts a number to an int64 if it has no fractional part or exponent and fits,
+// so that large integers keep their precision, or a float64 otherwise.
+func importNumber(n json.Number) (interface{}, error) {
+	if !strings.ContainsAny(string(n), ".eE") {
+		if i, err := n.Int64(); err == nil {
+			return i, nil
+		}
+	}
+	return n.Float64()
+}
+
+func writeImportLayer(
+	layer string,
+	typ shp.ShapeType,
+	features []importFeature,
+	warn func(int, string, string, ...interface{}),
+) error {
+	var fields []*importField
+	byName := make(map[string]int)
+	for _, feature := range features {
+		for _, prop := range feature.properties {
+			i, ok := byName[prop.name]
+			if !ok {
+				i = len(fields)
+				byName[prop.name] = i
+				fields = append(fields, &importField{property: prop.name})
+			}
+			fields[i].add(prop.value)
+		}
+	}
+
+	used := make(map[string]struct{})
+	descs := make([]*dbase5.FieldDesc, len(fields))
+	for i, f := range fields {
+		name := uniqueFieldName(f.property, used)
+		if name != f.property {
+			warn(-1, f.property, "renamed to '%s'", name)
+		}
+
+		var err error
+		if f.desc, err = f.fieldDesc(name, func(format string, args ...interface{}) {
+			warn(-1, f.property, format, args...)
+		}); err != nil {
+			return fmt.Errorf("failed to create field for property '%s': %w", f.property, err)
+		}
+		descs[i] = f.desc
+	}
+
+	files := make(map[string]*os.File)
+	defer func() {
+		for _, file := range files {
+			file.Close()
+		}
+	}()
+
+	for _, ext := range []string{".shp", ".shx", ".dbf"} {
+		file, err := os.Create(layer + ext)
+		if err != nil {
+			return fmt.Errorf("failed to create '%s': %w", layer+ext, err)
+		}
+		files[ext] = file
+	}
+
+	if err := ioutil.WriteFile(layer+".cpg", []byte("UTF-8"), 0644); err != nil {
+		return fmt.Errorf("failed to create '%s': %w", layer+".cpg", err)
+	}
+
+	shapes, err := shp.NewWriter(files[".shp"], files[".shx"], typ)
+	if err != nil {
+		return err
+	}
+
+	attrs, err := dbf.NewWriter(files[".dbf"], descs, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, feature := range features {
+		if err := shapes.Write(feature.shape); err != nil {
+			return err
+		}
+
+		values := make([]interface{}, len(fields))
+		for _, prop := range feature.properties {
+			i := byName[prop.name]
+			var err error
+			if values[i], err = fields[i].value(prop.value, func(format string, args ...interface{}) {
+				warn(feature.index, prop.name, format, args...)
+			}); err != nil {
+				return fmt.Errorf("failed to convert property '%s' of feature %d: %w", prop.name, feature.index, err)
+			}
+		}
+
+		if err := attrs.Write(values...); err != nil {
+			return err
+		}
+	}
+
+	if err := shapes.Close(); err != nil {
+		return err
+	} else if err := attrs.Close(); err != nil {
+		return err
+	}
+
+	for ext, file := range files {
+		if err := file.Close(); err != nil {
+			return fmt.Errorf("failed to close '%s': %w", layer+ext, err)
+		}
+		delete(files, ext)
+	}
+	return nil
+}
+
+// uniqueFieldName truncates the name to the maximum length of a dbf field name,
+// adding a numeric suffix if it's already used, ignoring case.
+func uniqueFieldName(name string, used map[string]struct{}) string {
+	base := truncateUTF8(name, maxFieldNameLen)
+	if base == "" {
+		base = "FIELD"
+	}
+
+	out := base
+	for i := 1; ; i++ {
+		if _, ok := used[strings.ToUpper(out)]; !ok {
+			break
+		}
+
+		suffix := "_" + strconv.Itoa(i)
+		out = truncateUTF8(base, maxFieldNameLen-len(suffix)) + suffix
+	}
+
+	used[strings.ToUpper(out)] = struct{}{}
+	return out
+}
+
+// importString converts any property value to a string, encoding objects and arrays as JSON.
+func importString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		buf, _ := json.Marshal(v)
+		return string(buf)
+	}
+}
+
+// truncateUTF8 shortens s to at most n bytes, without splitting a multi-byte character.
+func truncateUTF8(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,136 @@
+package shapefile_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/everystreet/go-shapefile"
+	"github.com/everystreet/go-shapefile/shp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportGeoJSON(t *testing.T) {
+	const collection = `{
+		"type": "FeatureCollection",
+		"features": [
+			{
+				"type": "Feature",
+				"geometry": {"type": "Point", "coordinates": [1.5, 2.5]},
+				"properties": {"name": "first", "population": 1200, "density": 1.25, "capital": true}
+			},
+			{
+				"type": "Feature",
+				"geometry": {"type": "Point", "coordinates": [5, 6]},
+				"properties": {"name": "second", "population": -5, "density": 0.125, "tags": ["a", "b"]}
+			},
+			{
+				"type": "Feature",
+				"geometry": {"type": "Polygon", "coordinates": [[[0, 0], [10, 0], [10, 10], [0, 10], [0, 0]]]},
+				"properties": {"a_very_long_property_name": "x", "id": 9007199254740993}
+			},
+			{
+				"type": "Feature",
+				"geometry": null,
+				"properties": {}
+			},
+			{
+				"type": "Feature",
+				"geometry": {"type": "MultiPoint", "coordinates": [[3, 4], [5, 6]]},
+				"properties": {}
+			}
+		]
+	}`
+
+	dir := t.TempDir()
+	report, err := shapefile.ImportGeoJSON(strings.NewReader(collection), dir, "places")
+	require.NoError(t, err)
+
+	require.Equal(t, []string{
+		filepath.Join(dir, "places_points"),
+		filepath.Join(dir, "places_polygons"),
+	}, report.Layers)
+
+	var warnings []string
+	for _, w := range report.Warnings {
+		warnings = append(warnings, w.String())
+	}
+	require.ElementsMatch(t, []string{
+		"feature 3: skipped: missing geometry",
+		"feature 4: skipped: unsupported geometry type 'MultiPoint'",
+		"property 'tags': mixed or nested values written as strings",
+		"property 'a_very_long_property_name': renamed to 'a_very_lon'",
+	}, warnings)
+
+	t.Run("points", func(t *testing.T) {
+		f, err := shapefile.OpenPath(report.Layers[0]+".shp",
+			shapefile.FilterFields("name", "population", "density", "tags"))
+		require.NoError(t, err)
+		defer f.Close()
+
+		info, err := f.Info()
+		require.NoError(t, err)
+		require.Equal(t, shp.PointType, info.ShapeType)
+		require.Equal(t, uint32(2), info.NumRecords)
+		require.Equal(t, shp.BoundingBox{MinX: 1.5, MinY: 2.5, MaxX: 5, MaxY: 6}, info.BoundingBox)
+
+		require.NoError(t, f.Scan())
+
+		var names []string
+		for {
+			rec := f.Record()
+			if rec == nil {
+				break
+			}
+
+			name, ok := rec.Field("name")
+			require.True(t, ok)
+			names = append(names, name.Value().(string))
+
+			if rec.Shape.RecordNumber() == 1 {
+				population, ok := rec.Field("population")
+				require.True(t, ok)
+				require.Equal(t, 1200.0, population.Value())
+
+				density, ok := rec.Field("density")
+				require.True(t, ok)
+				require.Equal(t, 1.25, density.Value())
+			} else {
+				tags, ok := rec.Field("tags")
+				require.True(t, ok)
+				require.Equal(t, `["a","b"]`, tags.Value())
+			}
+		}
+		require.NoError(t, f.Err())
+		require.Equal(t, []string{"first", "second"}, names)
+	})
+
+	t.Run("polygons", func(t *testing.T) {
+		f, err := shapefile.OpenPath(report.Layers[1] + ".shp")
+		require.NoError(t, err)
+		defer f.Close()
+
+		require.NoError(t, f.Scan())
+
+		rec := f.Record()
+		require.NotNil(t, rec)
+
+		polygon, ok := rec.Shape.(shp.Polygon)
+		require.True(t, ok)
+		require.True(t, polygon.Parts[0].IsClockwise())
+		require.Equal(t, 100.0, polygon.Area())
+
+		field, ok := rec.Field("a_very_lon")
+		require.True(t, ok)
+		require.Equal(t, "x", field.Value())
+
+		require.Nil(t, f.Record())
+		require.NoError(t, f.Err())
+
+		// 2^53+1 can't be represented by a float64
+		buf, err := ioutil.ReadFile(report.Layers[1] + ".dbf")
+		require.NoError(t, err)
+		require.Contains(t, string(buf), "9007199254740993")
+	})
+}
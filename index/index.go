@@ -0,0 +1,224 @@
+// Package index provides an in-memory R-tree over shapefile records, for bounding box and nearest neighbour queries.
+package index
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/everystreet/go-shapefile"
+	"github.com/everystreet/go-shapefile/shp"
+	"github.com/golang/geo/s2"
+)
+
+// Index is a static R-tree of records, bulk-loaded using Sort-Tile-Recursive packing.
+// It isn't modified once built, so it's safe for concurrent use.
+type Index struct {
+	root    *node
+	records map[uint32]*shapefile.Record
+}
+
+// node is either a leaf that holds a single record, or a branch with children.
+type node struct {
+	box      shp.BoundingBox
+	rect     s2.Rect
+	children []*node
+	record   *shapefile.Record
+}
+
+// New reads every record from the scanner and builds an index over their shapes.
+func New(s shapefile.Scannable, opts ...Option) (*Index, error) {
+	conf := defaultConfig()
+	for _, opt := range opts {
+		opt(&conf)
+	}
+
+	if conf.capacity < 2 {
+		return nil, fmt.Errorf("node capacity must be at least 2")
+	}
+
+	if err := s.Scan(); err != nil {
+		return nil, err
+	}
+
+	idx := &Index{
+		records: make(map[uint32]*shapefile.Record),
+	}
+
+	var leaves []*node
+	for {
+		rec := s.Record()
+		if rec == nil {
+			break
+		}
+
+		idx.records[rec.RecordNumber()] = rec
+		leaves = append(leaves, &node{
+			box:    shp.BoundingBoxOf(rec.Shape),
+			rect:   rectBound(rec.Shape),
+			record: rec,
+		})
+	}
+
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	idx.root = pack(leaves, conf.capacity)
+	return idx, nil
+}
+
+// Len returns the number of records in the index.
+func (idx *Index) Len() int {
+	return len(idx.records)
+}
+
+// BoundingBox returns the bounding box of every record in the index.
+func (idx *Index) BoundingBox() shp.BoundingBox {
+	if idx.root == nil {
+		return shp.BoundingBox{}
+	}
+	return idx.root.box
+}
+
+// Record returns the record with the specified number.
+func (idx *Index) Record(num uint32) (*shapefile.Record, bool) {
+	rec, ok := idx.records[num]
+	return rec, ok
+}
+
+// Attributes returns the attributes of the record with the specified number.
+// false is returned if there is no such record, or if it has no attributes.
+func (idx *Index) Attributes(num uint32) (shapefile.Attributes, bool) {
+	rec, ok := idx.records[num]
+	if !ok || rec.Attributes == nil {
+		return nil, false
+	}
+	return rec.Attributes, true
+}
+
+// Intersects returns the records whose shapes intersect the box, in order of record number.
+// Shapes are tested using shp.Clip, rather than just their bounding boxes.
+func (idx *Index) Intersects(box shp.BoundingBox) []*shapefile.Record {
+	return idx.search(box, func(rec *shapefile.Record) bool {
+		_, ok := shp.Clip(rec.Shape, box)
+		return ok
+	})
+}
+
+// Candidates returns the records whose bounding boxes intersect the box, in order of record number.
+func (idx *Index) Candidates(box shp.BoundingBox) []*shapefile.Record {
+	return idx.search(box, func(*shapefile.Record) bool {
+		return true
+	})
+}
+
+// search returns the records whose bounding boxes intersect the box, and for which match returns true.
+func (idx *Index) search(box shp.BoundingBox, match func(*shapefile.Record) bool) []*shapefile.Record {
+	if idx.root == nil {
+		return nil
+	}
+
+	var out []*shapefile.Record
+	stack := []*node{idx.root}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if !n.box.Intersects(box) {
+			continue
+		} else if n.record == nil {
+			stack = append(stack, n.children...)
+		} else if match(n.record) {
+			out = append(out, n.record)
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].RecordNumber() < out[j].RecordNumber()
+	})
+	return out
+}
+
+// pack builds the tree from the bottom up, returning the root.
+func pack(nodes []*node, capacity int) *node {
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	for len(nodes) > 1 {
+		nodes = packLevel(nodes, capacity)
+	}
+	return nodes[0]
+}
+
+// packLevel sorts the nodes into vertical slices by the X coordinate of their centres,
+// then groups each slice into parents by the Y coordinate of their centres.
+func packLevel(nodes []*node, capacity int) []*node {
+	numParents := int(math.Ceil(float64(len(nodes)) / float64(capacity)))
+	sliceSize := int(math.Ceil(math.Sqrt(float64(numParents)))) * capacity
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].box.MinX+nodes[i].box.MaxX < nodes[j].box.MinX+nodes[j].box.MaxX
+	})
+
+	parents := make([]*node, 0, numParents)
+	for i := 0; i < len(nodes); i += sliceSize {
+		slice := nodes[i:minInt(i+sliceSize, len(nodes))]
+		sort.Slice(slice, func(i, j int) bool {
+			return slice[i].box.MinY+slice[i].box.MaxY < slice[j].box.MinY+slice[j].box.MaxY
+		})
+
+		for j := 0; j < len(slice); j += capacity {
+			parents = append(parents, newBranch(slice[j:minInt(j+capacity, len(slice))]))
+		}
+	}
+	return parents
+}
+
+func newBranch(children []*node) *node {
+	n := &node{
+		box:      children[0].box,
+		rect:     children[0].rect,
+		children: children,
+	}
+
+	for _, c := range children[1:] {
+		n.box.MinX = math.Min(n.box.MinX, c.box.MinX)
+		n.box.MinY = math.Min(n.box.MinY, c.box.MinY)
+		n.box.MaxX = math.Max(n.box.MaxX, c.box.MaxX)
+		n.box.MaxY = math.Max(n.box.MaxY, c.box.MaxY)
+		n.rect = n.rect.Union(c.rect)
+	}
+	return n
+}
+
+// rectBound returns a latitude-longitude rectangle that bounds the geodesic edges of the shape.
+func rectBound(shape shp.Shape) s2.Rect {
+	var parts []shp.Part
+	switch s := shape.(type) {
+	case shp.Point:
+		return s2.RectFromLatLng(s2.LatLngFromPoint(s.S2Point()))
+	case shp.Polyline:
+		parts = s.Parts
+	case shp.Polygon:
+		parts = s.Parts
+	}
+
+	rect := s2.EmptyRect()
+	for _, part := range parts {
+		bounder := s2.NewRectBounder()
+		for _, p := range part {
+			bounder.AddPoint(p.S2Point())
+		}
+		rect = rect.Union(bounder.RectBound())
+	}
+	return rect
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,95 @@
+package index_test
+
+import (
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/everystreet/go-shapefile"
+	"github.com/everystreet/go-shapefile/index"
+	"github.com/everystreet/go-shapefile/shp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex(t *testing.T) {
+	idx := open(t, index.NodeCapacity(4))
+	require.Equal(t, 171, idx.Len())
+
+	box := idx.BoundingBox()
+	require.InDelta(t, -180, box.MinX, 1e-6)
+	require.InDelta(t, 180, box.MaxX, 1e-6)
+
+	// Around London
+	recs := idx.Intersects(shp.BoundingBox{MinX: -0.2, MinY: 51.4, MaxX: 0, MaxY: 51.6})
+	require.Len(t, recs, 1)
+	require.Equal(t, "United Kingdom", name(t, recs[0].Attributes))
+
+	attrs, ok := idx.Attributes(recs[0].RecordNumber())
+	require.True(t, ok)
+	require.Equal(t, "United Kingdom", name(t, attrs))
+
+	_, ok = idx.Record(1000)
+	require.False(t, ok)
+}
+
+func TestIndexNearest(t *testing.T) {
+	idx := open(t)
+
+	// Inside France
+	nearest := idx.Nearest(shp.MakePoint(2.35, 48.85), 3, index.Planar)
+	require.Len(t, nearest, 3)
+	require.Equal(t, "France", name(t, nearest[0].Attributes))
+	require.Equal(t, 0.0, nearest[0].Distance)
+	require.True(t, sort.SliceIsSorted(nearest, func(i, j int) bool {
+		return nearest[i].Distance < nearest[j].Distance
+	}))
+
+	// In the English Channel, closer to England than France
+	nearest = idx.Nearest(shp.MakePoint(-1.5, 50.3), 1, index.Geodesic)
+	require.Len(t, nearest, 1)
+	require.Equal(t, "United Kingdom", name(t, nearest[0].Attributes))
+	require.Greater(t, nearest[0].Distance, 1000.0)
+	require.Less(t, nearest[0].Distance, 100000.0)
+
+	all := idx.Nearest(shp.MakePoint(0, 0), 1000, index.Planar)
+	require.Len(t, all, idx.Len())
+	require.True(t, sort.SliceIsSorted(all, func(i, j int) bool {
+		return all[i].Distance < all[j].Distance
+	}))
+}
+
+func TestIndexConcurrentReads(t *testing.T) {
+	idx := open(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			x := float64(i*40 - 160)
+			require.NotNil(t, idx.Nearest(shp.MakePoint(x, 0), 5, index.Geodesic))
+			idx.Intersects(shp.BoundingBox{MinX: x, MinY: -10, MaxX: x + 10, MaxY: 10})
+		}(i)
+	}
+	wg.Wait()
+}
+
+func open(t *testing.T, opts ...index.Option) *index.Index {
+	t.Helper()
+
+	f, err := shapefile.OpenPath("../testdata/ne_110m_admin_0_sovereignty.shp")
+	require.NoError(t, err)
+	defer f.Close()
+
+	idx, err := index.New(f, opts...)
+	require.NoError(t, err)
+	return idx
+}
+
+func name(t *testing.T, attrs shapefile.Attributes) string {
+	t.Helper()
+
+	f, ok := attrs.Field("NAME")
+	require.True(t, ok)
+	return f.Value().(string)
+}
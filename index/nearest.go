@@ -0,0 +1,190 @@
+package index
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/everystreet/go-shapefile"
+	"github.com/everystreet/go-shapefile/shp"
+	"github.com/golang/geo/s1"
+	"github.com/golang/geo/s2"
+)
+
+// Metric is a way of measuring the distance between a point and a shape.
+type Metric uint
+
+// Distance metrics.
+const (
+	// Planar measures straight line distances in the units of the shape coordinates.
+	Planar Metric = iota
+
+	// Geodesic measures distances in metres over the surface of the earth, where X is longitude and Y is latitude.
+	Geodesic
+)
+
+// earthRadius is the mean radius of the earth in metres, as used by the shp package.
+const earthRadius = 6371008.8
+
+// Neighbour is a record found by a nearest neighbour query, and its distance from the query point.
+type Neighbour struct {
+	*shapefile.Record
+	Distance float64
+}
+
+// Nearest returns up to k records whose shapes are closest to the point, in order of increasing distance.
+// The distance to a polygon that contains the point is zero, where containment is tested using shp.Polygon.Contains.
+func (idx *Index) Nearest(p shp.Point, k int, metric Metric) []Neighbour {
+	if idx.root == nil || k <= 0 {
+		return nil
+	}
+
+	var bound func(*node) float64
+	var distance func(shp.Shape) float64
+	switch metric {
+	case Geodesic:
+		ll, x := s2.LatLngFromDegrees(p.Y, p.X), p.S2Point()
+		bound = func(n *node) float64 {
+			return n.rect.DistanceToLatLng(ll).Radians() * earthRadius
+		}
+		distance = func(shape shp.Shape) float64 {
+			return geodesicDistance(shape, p, x)
+		}
+	default:
+		bound = func(n *node) float64 {
+			return boxDistance(n.box, p)
+		}
+		distance = func(shape shp.Shape) float64 {
+			return planarDistance(shape, p)
+		}
+	}
+
+	// Best-first search, where leaves are pushed back with their exact distance before being returned
+	q := &queue{{node: idx.root, distance: bound(idx.root)}}
+	out := make([]Neighbour, 0, k)
+	for q.Len() > 0 && len(out) < k {
+		item := heap.Pop(q).(queueItem)
+		switch {
+		case item.exact:
+			out = append(out, Neighbour{
+				Record:   item.node.record,
+				Distance: item.distance,
+			})
+		case item.node.record != nil:
+			heap.Push(q, queueItem{
+				node:     item.node,
+				distance: distance(item.node.record.Shape),
+				exact:    true,
+			})
+		default:
+			for _, c := range item.node.children {
+				heap.Push(q, queueItem{
+					node:     c,
+					distance: bound(c),
+				})
+			}
+		}
+	}
+	return out
+}
+
+func boxDistance(box shp.BoundingBox, p shp.Point) float64 {
+	dx := math.Max(0, math.Max(box.MinX-p.X, p.X-box.MaxX))
+	dy := math.Max(0, math.Max(box.MinY-p.Y, p.Y-box.MaxY))
+	return math.Hypot(dx, dy)
+}
+
+func planarDistance(shape shp.Shape, p shp.Point) float64 {
+	var parts []shp.Part
+	switch s := shape.(type) {
+	case shp.Point:
+		return p.Distance(s)
+	case shp.Polyline:
+		parts = s.Parts
+	case shp.Polygon:
+		if s.Contains(p) {
+			return 0
+		}
+		parts = s.Parts
+	}
+
+	min := math.Inf(1)
+	for _, part := range parts {
+		if len(part) == 1 {
+			min = math.Min(min, p.Distance(part[0]))
+		}
+		for i := 1; i < len(part); i++ {
+			min = math.Min(min, segmentDistance(p, part[i-1], part[i]))
+		}
+	}
+	return min
+}
+
+// segmentDistance returns the distance from p to the line segment between a and b.
+func segmentDistance(p, a, b shp.Point) float64 {
+	ab := b.Sub(a.Point)
+	length := ab.Dot(ab)
+	if length == 0 {
+		return p.Distance(a)
+	}
+
+	t := math.Max(0, math.Min(1, p.Sub(a.Point).Dot(ab)/length))
+	return p.Sub(a.Add(ab.Mul(t))).Norm()
+}
+
+func geodesicDistance(shape shp.Shape, p shp.Point, x s2.Point) float64 {
+	var parts []shp.Part
+	switch s := shape.(type) {
+	case shp.Point:
+		return p.GeodesicDistance(s)
+	case shp.Polyline:
+		parts = s.Parts
+	case shp.Polygon:
+		if s.Contains(p) {
+			return 0
+		}
+		parts = s.Parts
+	}
+
+	min := s1.InfAngle()
+	for _, part := range parts {
+		var prev s2.Point
+		for i, point := range part {
+			cur := point.S2Point()
+			var d s1.Angle
+			if i == 0 {
+				d = x.Distance(cur)
+			} else {
+				d = s2.DistanceFromSegment(x, prev, cur)
+			}
+
+			if d < min {
+				min = d
+			}
+			prev = cur
+		}
+	}
+	return min.Radians() * earthRadius
+}
+
+type queueItem struct {
+	node     *node
+	distance float64
+	exact    bool
+}
+
+type queue []queueItem
+
+func (q queue) Len() int           { return len(q) }
+func (q queue) Less(i, j int) bool { return q[i].distance < q[j].distance }
+func (q queue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func (q *queue) Push(x interface{}) {
+	*q = append(*q, x.(queueItem))
+}
+
+func (q *queue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
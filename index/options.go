@@ -0,0 +1,21 @@
+package index
+
+// Option funcs can be passed to New().
+type Option func(*config)
+
+// NodeCapacity sets the maximum number of entries in each node of the tree. The default is 16.
+func NodeCapacity(n int) Option {
+	return func(c *config) {
+		c.capacity = n
+	}
+}
+
+type config struct {
+	capacity int
+}
+
+func defaultConfig() config {
+	return config{
+		capacity: 16,
+	}
+}
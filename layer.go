@@ -0,0 +1,107 @@
+package shapefile
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Layer describes a single shapefile found inside an archive or directory,
+// consisting of a .shp file and any sibling files that share its base name.
+type Layer struct {
+	// Name is the path of the layer inside its container, without an extension.
+	Name string
+
+	files map[string]string
+}
+
+// File returns the name of the member with the specified extension, such as ".dbf".
+// Extensions are matched regardless of case.
+func (l Layer) File(ext string) (string, bool) {
+	name, ok := l.files[strings.ToLower(ext)]
+	return name, ok
+}
+
+// layerExts are the extensions of files that belong to a layer.
+var layerExts = map[string]struct{}{
+	".shp": {},
+	".dbf": {},
+	".shx": {},
+	".cpg": {},
+	".prj": {},
+	".dbt": {},
+	".fpt": {},
+}
+
+// findLayers groups member names into layers by matching siblings on base name.
+// Layers are only returned if they include a .shp file.
+func findLayers(names []string) []Layer {
+	byName := make(map[string]Layer)
+	for _, name := range names {
+		ext := strings.ToLower(path.Ext(name))
+		if _, ok := layerExts[ext]; !ok {
+			continue
+		}
+
+		base := strings.TrimSuffix(name, path.Ext(name))
+		l, ok := byName[base]
+		if !ok {
+			l = Layer{
+				Name:  base,
+				files: make(map[string]string),
+			}
+			byName[base] = l
+		}
+		l.files[ext] = name
+	}
+
+	layers := make([]Layer, 0, len(byName))
+	for _, l := range byName {
+		if _, ok := l.files[".shp"]; ok {
+			layers = append(layers, l)
+		}
+	}
+
+	sort.Slice(layers, func(i, j int) bool {
+		return layers[i].Name < layers[j].Name
+	})
+	return layers
+}
+
+// selectLayer returns the named layer.
+// If name is empty, there must be exactly one layer.
+// Otherwise, the name must match either the full layer name or the base name of a single layer.
+func selectLayer(layers []Layer, name string) (Layer, error) {
+	if name == "" {
+		switch len(layers) {
+		case 0:
+			return Layer{}, fmt.Errorf("missing .shp file")
+		case 1:
+			return layers[0], nil
+		default:
+			return Layer{}, fmt.Errorf("found multiple .shp files")
+		}
+	}
+
+	for _, l := range layers {
+		if l.Name == name {
+			return l, nil
+		}
+	}
+
+	var match *Layer
+	for i, l := range layers {
+		if path.Base(l.Name) != name {
+			continue
+		} else if match != nil {
+			return Layer{}, fmt.Errorf("found multiple layers named '%s'", name)
+		}
+		match = &layers[i]
+	}
+
+	if match == nil {
+		return Layer{}, fmt.Errorf("missing .shp file for layer '%s'", name)
+	}
+	return *match, nil
+}
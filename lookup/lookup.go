@@ -0,0 +1,120 @@
+// Package lookup answers reverse geocoding queries, finding the polygon that contains a point.
+package lookup
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/everystreet/go-shapefile"
+	"github.com/everystreet/go-shapefile/index"
+	"github.com/everystreet/go-shapefile/shp"
+)
+
+// Lookup finds the polygons of a shapefile that contain points, where X is longitude and Y is latitude.
+// It's safe for concurrent use, and can be used as an http.Handler.
+type Lookup struct {
+	idx *index.Index
+}
+
+// New reads every record from a polygon shapefile and indexes them for lookups.
+func New(s shapefile.Scannable, opts ...index.Option) (*Lookup, error) {
+	info, err := s.Info()
+	if err != nil {
+		return nil, err
+	} else if info.ShapeType != shp.PolygonType {
+		return nil, fmt.Errorf("expecting %s shapes but have %s", shp.PolygonType, info.ShapeType)
+	}
+
+	idx, err := index.New(s, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Lookup{idx: idx}, nil
+}
+
+// Query returns the record whose polygon contains the point, excluding any holes.
+// If several polygons overlap at the point, the one with the lowest record number is returned.
+func (l *Lookup) Query(p shp.Point) (*shapefile.Record, bool) {
+	box := shp.BoundingBox{MinX: p.X, MinY: p.Y, MaxX: p.X, MaxY: p.Y}
+	for _, rec := range l.idx.Candidates(box) {
+		if polygon, ok := rec.Shape.(shp.Polygon); ok && polygon.Contains(p) {
+			return rec, true
+		}
+	}
+	return nil, false
+}
+
+// Response is the JSON body written by ServeHTTP when a polygon contains the point.
+type Response struct {
+	Record     uint32                 `json:"record"`
+	Attributes map[string]interface{} `json:"attributes"`
+}
+
+// ServeHTTP answers GET requests with lat and lng query parameters in decimal degrees.
+// The attributes of the containing polygon are written as a JSON Response,
+// and 404 Not Found is returned if no polygon contains the point.
+func (l *Lookup) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s is not allowed", r.Method))
+		return
+	}
+
+	lat, err := parseDegrees(r, "lat", 90)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	lng, err := parseDegrees(r, "lng", 180)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	rec, ok := l.Query(shp.MakePoint(lng, lat))
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no polygon contains the point"))
+		return
+	}
+
+	resp := Response{
+		Record:     rec.RecordNumber(),
+		Attributes: make(map[string]interface{}),
+	}
+	if rec.Attributes != nil {
+		for _, f := range rec.Fields() {
+			resp.Attributes[f.Name()] = f.Value()
+		}
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func parseDegrees(r *http.Request, name string, limit float64) (float64, error) {
+	str := r.URL.Query().Get(name)
+	if str == "" {
+		return 0, fmt.Errorf("missing '%s' parameter", name)
+	}
+
+	v, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid '%s' parameter: %w", name, err)
+	} else if v < -limit || v > limit {
+		return 0, fmt.Errorf("'%s' must be between -%g and %g", name, limit, limit)
+	}
+	return v, nil
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	writeJSON(w, code, struct {
+		Error string `json:"error"`
+	}{err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
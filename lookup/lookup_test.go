@@ -0,0 +1,94 @@
+package lookup_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/everystreet/go-shapefile"
+	"github.com/everystreet/go-shapefile/lookup"
+	"github.com/everystreet/go-shapefile/shp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookup(t *testing.T) {
+	l := open(t)
+
+	rec, ok := l.Query(shp.MakePoint(2.35, 48.85))
+	require.True(t, ok)
+	require.Equal(t, "France", name(t, rec))
+
+	// Lesotho is a hole in South Africa
+	rec, ok = l.Query(shp.MakePoint(28.2, -29.5))
+	require.True(t, ok)
+	require.Equal(t, "Lesotho", name(t, rec))
+
+	rec, ok = l.Query(shp.MakePoint(25, -30))
+	require.True(t, ok)
+	require.Equal(t, "South Africa", name(t, rec))
+
+	_, ok = l.Query(shp.MakePoint(-30, 40))
+	require.False(t, ok)
+}
+
+func TestLookupHandler(t *testing.T) {
+	srv := httptest.NewServer(open(t))
+	defer srv.Close()
+
+	t.Run("found", func(t *testing.T) {
+		resp, err := http.Get(srv.URL + "?lat=48.85&lng=2.35")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+		var body lookup.Response
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		require.NotZero(t, body.Record)
+		require.Equal(t, "France", body.Attributes["NAME"])
+	})
+
+	for _, tt := range []struct {
+		name, query string
+		code        int
+	}{
+		{"not found", "?lat=40&lng=-30", http.StatusNotFound},
+		{"missing", "?lat=40", http.StatusBadRequest},
+		{"invalid", "?lat=40&lng=east", http.StatusBadRequest},
+		{"out of range", "?lat=100&lng=0", http.StatusBadRequest},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := http.Get(srv.URL + tt.query)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			require.Equal(t, tt.code, resp.StatusCode)
+
+			var body struct{ Error string }
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			require.NotEmpty(t, body.Error)
+		})
+	}
+}
+
+func open(t *testing.T) *lookup.Lookup {
+	t.Helper()
+
+	f, err := shapefile.OpenPath("../testdata/ne_110m_admin_0_sovereignty.shp")
+	require.NoError(t, err)
+	defer f.Close()
+
+	l, err := lookup.New(f)
+	require.NoError(t, err)
+	return l
+}
+
+func name(t *testing.T, rec *shapefile.Record) string {
+	t.Helper()
+
+	f, ok := rec.Field("NAME")
+	require.True(t, ok)
+	return f.Value().(string)
+}
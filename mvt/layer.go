@@ -0,0 +1,377 @@
+package mvt
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/everystreet/go-shapefile"
+	"github.com/everystreet/go-shapefile/shp"
+)
+
+// Layer is a single layer of a Mapbox Vector Tile, consisting of features created from shapefile records.
+// Coordinates are expected to be longitude (X) and latitude (Y), and are projected into Web Mercator tile space.
+type Layer struct {
+	name string
+	tile Tile
+	conf config
+
+	keys     []string
+	keyIndex map[string]uint32
+
+	values     []value
+	valueIndex map[value]uint32
+
+	features [][]byte
+}
+
+// NewLayer creates an empty layer for the specified tile.
+func NewLayer(name string, tile Tile, opts ...Option) *Layer {
+	conf := defaultConfig()
+	for _, opt := range opts {
+		opt(&conf)
+	}
+
+	return &Layer{
+		name:       name,
+		tile:       tile,
+		conf:       conf,
+		keyIndex:   make(map[string]uint32),
+		valueIndex: make(map[value]uint32),
+	}
+}
+
+// Add encodes the record as a feature of the layer.
+// The shape is clipped to the tile extent plus the buffer, and records that are entirely outside of it are skipped.
+// Attributes are encoded as feature tags, with duplicate keys and values stored once per layer.
+func (l *Layer) Add(rec *shapefile.Record) error {
+	var geomType uint64
+	var geometry []uint32
+	switch shape := rec.Shape.(type) {
+	case shp.Point:
+		geomType, geometry = 1, l.point(shape)
+	case shp.Polyline:
+		geomType, geometry = 2, l.polyline(shape)
+	case shp.Polygon:
+		geomType, geometry = 3, l.polygon(shape)
+	default:
+		return fmt.Errorf("unsupported shape type %s", rec.Shape.Type())
+	}
+
+	if len(geometry) == 0 {
+		return nil
+	}
+
+	var feature []byte
+	feature = appendUint(feature, 1, uint64(rec.Shape.RecordNumber()))
+	if tags := l.tags(rec.Attributes); len(tags) > 0 {
+		feature = appendPacked(feature, 2, tags)
+	}
+	feature = appendUint(feature, 3, geomType)
+	feature = appendPacked(feature, 4, geometry)
+
+	l.features = append(l.features, feature)
+	return nil
+}
+
+// Len returns the number of features in the layer.
+func (l *Layer) Len() int {
+	return len(l.features)
+}
+
+// Marshal returns the protobuf encoding of a tile consisting of only this layer.
+func (l *Layer) Marshal() []byte {
+	return Marshal(l)
+}
+
+// Marshal returns the protobuf encoding of a tile consisting of the supplied layers.
+func Marshal(layers ...*Layer) []byte {
+	var tile []byte
+	for _, l := range layers {
+		tile = appendBytes(tile, 3, l.encode())
+	}
+	return tile
+}
+
+func (l *Layer) encode() []byte {
+	var buf []byte
+	buf = appendUint(buf, 15, 2)
+	buf = appendString(buf, 1, l.name)
+	for _, f := range l.features {
+		buf = appendBytes(buf, 2, f)
+	}
+	for _, k := range l.keys {
+		buf = appendString(buf, 3, k)
+	}
+	for _, v := range l.values {
+		buf = appendBytes(buf, 4, v.encode())
+	}
+	buf = appendUint(buf, 5, uint64(l.conf.extent))
+	return buf
+}
+
+func (l *Layer) tags(attrs shapefile.Attributes) []uint32 {
+	if attrs == nil {
+		return nil
+	}
+
+	fields := attrs.Fields()
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Name() < fields[j].Name()
+	})
+
+	tags := make([]uint32, 0, len(fields)*2)
+	for _, f := range fields {
+		v, ok := makeValue(f.Value())
+		if !ok {
+			continue
+		}
+
+		k, ok := l.keyIndex[f.Name()]
+		if !ok {
+			k = uint32(len(l.keys))
+			l.keys = append(l.keys, f.Name())
+			l.keyIndex[f.Name()] = k
+		}
+
+		i, ok := l.valueIndex[v]
+		if !ok {
+			i = uint32(len(l.values))
+			l.values = append(l.values, v)
+			l.valueIndex[v] = i
+		}
+
+		tags = append(tags, k, i)
+	}
+	return tags
+}
+
+// Geometry command IDs.
+const (
+	cmdMoveTo    = 1
+	cmdLineTo    = 2
+	cmdClosePath = 7
+)
+
+func command(id, count uint32) uint32 {
+	return id&0x7 | count<<3
+}
+
+// clipBox returns the tile extent plus the buffer.
+// Tile space is flipped vertically so that rings keep their orientation while clipping.
+func (l *Layer) clipBox() shp.BoundingBox {
+	return shp.BoundingBox{
+		MinX: -float64(l.conf.buffer),
+		MinY: -float64(l.conf.extent + l.conf.buffer),
+		MaxX: float64(l.conf.extent + l.conf.buffer),
+		MaxY: float64(l.conf.buffer),
+	}
+}
+
+// project converts a point into flipped tile space.
+func (l *Layer) project(p shp.Point) shp.Point {
+	x, y := project(p.X, p.Y, l.tile.Z)
+	extent := float64(l.conf.extent)
+	return shp.MakePoint((x-float64(l.tile.X))*extent, -(y-float64(l.tile.Y))*extent)
+}
+
+func (l *Layer) projectParts(parts []shp.Part) []shp.Part {
+	out := make([]shp.Part, len(parts))
+	for i, part := range parts {
+		out[i] = make(shp.Part, len(part))
+		for j, p := range part {
+			out[i][j] = l.project(p)
+		}
+	}
+	return out
+}
+
+func (l *Layer) point(p shp.Point) []uint32 {
+	pt, ok := l.project(p).Clip(l.clipBox())
+	if !ok {
+		return nil
+	}
+
+	var c cursor
+	return append([]uint32{command(cmdMoveTo, 1)}, c.moveTo(round(pt))...)
+}
+
+func (l *Layer) polyline(p shp.Polyline) []uint32 {
+	parts := l.projectParts(p.Parts)
+	clipped, ok := shp.Polyline{BoundingBox: bounds(parts), Parts: parts}.Clip(l.clipBox())
+	if !ok {
+		return nil
+	}
+
+	var c cursor
+	var geometry []uint32
+	for _, part := range clipped.Parts {
+		line := dedupe(part)
+		if len(line) < 2 {
+			continue
+		}
+
+		geometry = append(geometry, command(cmdMoveTo, 1))
+		geometry = append(geometry, c.moveTo(line[0])...)
+		geometry = append(geometry, command(cmdLineTo, uint32(len(line)-1)))
+		for _, pt := range line[1:] {
+			geometry = append(geometry, c.moveTo(pt)...)
+		}
+	}
+	return geometry
+}
+
+func (l *Layer) polygon(p shp.Polygon) []uint32 {
+	parts := l.projectParts(p.Parts)
+	clipped, ok := shp.Polygon{BoundingBox: bounds(parts), Parts: parts}.Clip(l.clipBox())
+	if !ok {
+		return nil
+	}
+
+	var c cursor
+	var geometry []uint32
+	for _, polygon := range clipped.Polygons() {
+		for i, part := range polygon.Parts {
+			ring := dedupe(part)
+			if len(ring) > 1 && ring[0] == ring[len(ring)-1] {
+				ring = ring[:len(ring)-1]
+			}
+
+			if len(ring) < 3 {
+				if i == 0 {
+					break // skip holes of a collapsed exterior ring
+				}
+				continue
+			}
+
+			// Exterior rings must have a positive area in tile space, and interior rings a negative area
+			if area := signedArea(ring); area == 0 {
+				continue
+			} else if (i == 0) != (area > 0) {
+				for a, b := 0, len(ring)-1; a < b; a, b = a+1, b-1 {
+					ring[a], ring[b] = ring[b], ring[a]
+				}
+			}
+
+			geometry = append(geometry, command(cmdMoveTo, 1))
+			geometry = append(geometry, c.moveTo(ring[0])...)
+			geometry = append(geometry, command(cmdLineTo, uint32(len(ring)-1)))
+			for _, pt := range ring[1:] {
+				geometry = append(geometry, c.moveTo(pt)...)
+			}
+			geometry = append(geometry, command(cmdClosePath, 1))
+		}
+	}
+	return geometry
+}
+
+// point is an integer coordinate in tile space.
+type point struct {
+	x, y int64
+}
+
+// round converts a point in flipped tile space to integer tile coordinates.
+func round(p shp.Point) point {
+	return point{int64(math.Round(p.X)), int64(math.Round(-p.Y))}
+}
+
+// dedupe rounds the points of a part, removing consecutive duplicates.
+func dedupe(part shp.Part) []point {
+	out := make([]point, 0, len(part))
+	for _, p := range part {
+		pt := round(p)
+		if len(out) == 0 || out[len(out)-1] != pt {
+			out = append(out, pt)
+		}
+	}
+	return out
+}
+
+// signedArea returns the area of the ring using the formula in the vector tile specification.
+func signedArea(ring []point) int64 {
+	var sum int64
+	for i := range ring {
+		a, b := ring[i], ring[(i+1)%len(ring)]
+		sum += a.x*b.y - b.x*a.y
+	}
+	return sum
+}
+
+func bounds(parts []shp.Part) shp.BoundingBox {
+	return shp.Shapes{shp.Polyline{Parts: parts}}.BoundingBox()
+}
+
+// cursor tracks the position of the previous command, as parameters are encoded relative to it.
+type cursor struct {
+	point
+}
+
+func (c *cursor) moveTo(p point) []uint32 {
+	dx, dy := p.x-c.x, p.y-c.y
+	c.point = p
+	return []uint32{uint32(zigzag(dx)), uint32(zigzag(dy))}
+}
+
+// value is a single tag value, of which only one member is set according to the kind.
+type value struct {
+	kind uint8
+	s    string
+	d    float64
+	i    int64
+	b    bool
+}
+
+// Value kinds, which are also the protobuf field numbers.
+const (
+	stringValue = 1
+	doubleValue = 3
+	sintValue   = 6
+	boolValue   = 7
+)
+
+func makeValue(v interface{}) (value, bool) {
+	switch v := v.(type) {
+	case nil:
+		return value{}, false
+	case string:
+		return value{kind: stringValue, s: v}, true
+	case bool:
+		return value{kind: boolValue, b: v}, true
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+			return value{kind: sintValue, i: int64(v)}, true
+		}
+		return value{kind: doubleValue, d: v}, true
+	case int:
+		return value{kind: sintValue, i: int64(v)}, true
+	case int64:
+		return value{kind: sintValue, i: v}, true
+	case *time.Time:
+		if v == nil {
+			return value{}, false
+		}
+		return value{kind: stringValue, s: v.Format("2006-01-02")}, true
+	case time.Time:
+		return value{kind: stringValue, s: v.Format(time.RFC3339)}, true
+	default:
+		return value{kind: stringValue, s: fmt.Sprint(v)}, true
+	}
+}
+
+func (v value) encode() []byte {
+	switch v.kind {
+	case stringValue:
+		return appendString(nil, stringValue, v.s)
+	case doubleValue:
+		return appendDouble(nil, doubleValue, v.d)
+	case sintValue:
+		return appendUint(nil, sintValue, zigzag(v.i))
+	default:
+		b := uint64(0)
+		if v.b {
+			b = 1
+		}
+		return appendUint(nil, boolValue, b)
+	}
+}
@@ -0,0 +1,192 @@
+package mvt_test
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/everystreet/go-shapefile"
+	"github.com/everystreet/go-shapefile/dbf"
+	"github.com/everystreet/go-shapefile/mvt"
+	"github.com/everystreet/go-shapefile/shp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLayer(t *testing.T) {
+	l := mvt.NewLayer("places", mvt.Tile{Z: 0, X: 0, Y: 0})
+
+	require.NoError(t, l.Add(&shapefile.Record{
+		Shape: shp.MakePoint(0, 0),
+		Attributes: attrs{
+			field{"name", "Null Island"},
+			field{"rank", 1.0},
+		},
+	}))
+
+	require.NoError(t, l.Add(&shapefile.Record{
+		Shape: shp.MakePoint(90, 0),
+		Attributes: attrs{
+			field{"name", "Elsewhere"},
+			field{"rank", 1.0},
+			field{"empty", nil},
+		},
+	}))
+
+	require.Equal(t, 2, l.Len())
+
+	tile := decode(t, l.Marshal())
+	require.Len(t, tile[3], 1)
+
+	layer := decode(t, tile[3][0].([]byte))
+	require.Equal(t, "places", string(layer[1][0].([]byte)))
+	require.Equal(t, uint64(2), layer[15][0])
+	require.Equal(t, uint64(4096), layer[5][0])
+
+	// Keys and values are only stored once
+	require.Len(t, layer[3], 2)
+	require.Equal(t, "name", string(layer[3][0].([]byte)))
+	require.Equal(t, "rank", string(layer[3][1].([]byte)))
+	require.Len(t, layer[4], 3)
+
+	require.Len(t, layer[2], 2)
+	feature := decode(t, layer[2][0].([]byte))
+	require.Equal(t, uint64(1), feature[3][0])
+	require.Equal(t, []uint64{0, 0, 1, 1}, packed(t, feature[2][0].([]byte)))
+	require.Equal(t, []uint64{9, 4096, 4096}, packed(t, feature[4][0].([]byte)))
+
+	feature = decode(t, layer[2][1].([]byte))
+	require.Equal(t, []uint64{0, 2, 1, 1}, packed(t, feature[2][0].([]byte)))
+	require.Equal(t, []uint64{9, 6144, 4096}, packed(t, feature[4][0].([]byte)))
+}
+
+func TestLayerPolygon(t *testing.T) {
+	tile := mvt.Tile{Z: 1, X: 1, Y: 0}
+	l := mvt.NewLayer("polygons", tile, mvt.Buffer(0))
+
+	// Covers the whole of the northern hemisphere, so will be clipped to the tile extent
+	require.NoError(t, l.Add(&shapefile.Record{
+		Shape: shp.Polygon{
+			BoundingBox: shp.BoundingBox{MinX: -180, MinY: 0, MaxX: 180, MaxY: 85},
+			Parts: []shp.Part{{
+				shp.MakePoint(-180, 0),
+				shp.MakePoint(-180, 85),
+				shp.MakePoint(180, 85),
+				shp.MakePoint(180, 0),
+				shp.MakePoint(-180, 0),
+			}},
+		},
+	}))
+
+	layer := decode(t, decode(t, l.Marshal())[3][0].([]byte))
+	feature := decode(t, layer[2][0].([]byte))
+	require.Equal(t, uint64(3), feature[3][0])
+
+	geometry := packed(t, feature[4][0].([]byte))
+	require.Equal(t, uint64(9), geometry[0])   // MoveTo(1)
+	require.Equal(t, uint64(26), geometry[3])  // LineTo(3)
+	require.Equal(t, uint64(15), geometry[10]) // ClosePath(1)
+
+	// Check that the exterior ring has a positive area
+	params := append([]uint64{geometry[1], geometry[2]}, geometry[4:10]...)
+
+	var x, y, area int64
+	var ring [][2]int64
+	for i := 0; i < len(params); i += 2 {
+		x += unzigzag(params[i])
+		y += unzigzag(params[i+1])
+		ring = append(ring, [2]int64{x, y})
+	}
+	for i := range ring {
+		a, b := ring[i], ring[(i+1)%len(ring)]
+		area += a[0]*b[1] - b[0]*a[1]
+	}
+	require.Greater(t, area, int64(0))
+
+	bounds := tile.Bounds()
+	require.Equal(t, 0.0, bounds.MinX)
+	require.Equal(t, 180.0, bounds.MaxX)
+	require.InDelta(t, 0, bounds.MinY, 1e-9)
+	require.InDelta(t, 85.0511, bounds.MaxY, 1e-4)
+}
+
+// decode parses a protobuf message into a map of field numbers to values,
+// which are either uint64 (varint and fixed64) or []byte.
+func decode(t *testing.T, buf []byte) map[uint64][]interface{} {
+	out := make(map[uint64][]interface{})
+	for len(buf) > 0 {
+		tag, n := binary.Uvarint(buf)
+		require.True(t, n > 0)
+		buf = buf[n:]
+
+		switch tag & 0x7 {
+		case 0:
+			v, n := binary.Uvarint(buf)
+			require.True(t, n > 0)
+			buf = buf[n:]
+			out[tag>>3] = append(out[tag>>3], v)
+		case 1:
+			out[tag>>3] = append(out[tag>>3], binary.LittleEndian.Uint64(buf))
+			buf = buf[8:]
+		case 2:
+			l, n := binary.Uvarint(buf)
+			require.True(t, n > 0)
+			out[tag>>3] = append(out[tag>>3], buf[n:n+int(l)])
+			buf = buf[n+int(l):]
+		default:
+			t.Fatalf("unexpected wire type %d", tag&0x7)
+		}
+	}
+	return out
+}
+
+func packed(t *testing.T, buf []byte) []uint64 {
+	var out []uint64
+	for len(buf) > 0 {
+		v, n := binary.Uvarint(buf)
+		require.True(t, n > 0)
+		out = append(out, v)
+		buf = buf[n:]
+	}
+	return out
+}
+
+func unzigzag(v uint64) int64 {
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+type attrs []dbf.Field
+
+func (a attrs) Fields() []dbf.Field {
+	out := make([]dbf.Field, len(a))
+	copy(out, a)
+	return out
+}
+
+func (a attrs) Field(name string) (dbf.Field, bool) {
+	for _, f := range a {
+		if f.Name() == name {
+			return f, true
+		}
+	}
+	return nil, false
+}
+
+func (a attrs) Deleted() bool {
+	return false
+}
+
+type field struct {
+	name  string
+	value interface{}
+}
+
+func (f field) Name() string {
+	return f.name
+}
+
+func (f field) Value() interface{} {
+	return f.value
+}
+
+func (f field) Equal(string) bool {
+	return false
+}
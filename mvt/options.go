@@ -0,0 +1,32 @@
+package mvt
+
+// Option funcs can be passed to NewLayer().
+type Option func(*config)
+
+// Extent sets the number of units along each edge of the tile. The default is 4096.
+func Extent(e uint32) Option {
+	return func(c *config) {
+		c.extent = e
+	}
+}
+
+// Buffer sets the number of units outside of each edge of the tile that geometries are clipped to,
+// which prevents rendering artefacts at tile boundaries. The default is 64.
+func Buffer(b uint32) Option {
+	return func(c *config) {
+		c.buffer = b
+	}
+}
+
+// Config for layer encoding.
+type config struct {
+	extent uint32
+	buffer uint32
+}
+
+func defaultConfig() config {
+	return config{
+		extent: 4096,
+		buffer: 64,
+	}
+}
@@ -0,0 +1,59 @@
+package mvt
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// Protocol buffer wire types.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+)
+
+func appendTag(buf []byte, field, wireType uint64) []byte {
+	return appendVarint(buf, field<<3|wireType)
+}
+
+func appendVarint(buf []byte, v uint64) []byte {
+	for v >= 0x80 {
+		buf = append(buf, byte(v)|0x80)
+		v >>= 7
+	}
+	return append(buf, byte(v))
+}
+
+func appendBytes(buf []byte, field uint64, data []byte) []byte {
+	buf = appendTag(buf, field, wireBytes)
+	buf = appendVarint(buf, uint64(len(data)))
+	return append(buf, data...)
+}
+
+func appendString(buf []byte, field uint64, s string) []byte {
+	return appendBytes(buf, field, []byte(s))
+}
+
+func appendUint(buf []byte, field, v uint64) []byte {
+	buf = appendTag(buf, field, wireVarint)
+	return appendVarint(buf, v)
+}
+
+func appendDouble(buf []byte, field uint64, v float64) []byte {
+	buf = appendTag(buf, field, wireFixed64)
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, math.Float64bits(v))
+	return append(buf, b...)
+}
+
+func appendPacked(buf []byte, field uint64, values []uint32) []byte {
+	var packed []byte
+	for _, v := range values {
+		packed = appendVarint(packed, uint64(v))
+	}
+	return appendBytes(buf, field, packed)
+}
+
+func zigzag(v int64) uint64 {
+	return uint64((v << 1) ^ (v >> 63))
+}
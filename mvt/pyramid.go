@@ -0,0 +1,124 @@
+package mvt
+
+import (
+	"fmt"
+
+	"github.com/everystreet/go-shapefile"
+	"github.com/everystreet/go-shapefile/shp"
+)
+
+// Pyramid generates the tiles of a single layer for a range of zoom levels.
+type Pyramid struct {
+	// Layer is the name of the layer in each tile.
+	Layer string
+
+	MinZoom uint32
+	MaxZoom uint32
+
+	// Tolerance is the Douglas-Peucker simplification tolerance, measured in tile units at each zoom level.
+	// Shapes are not simplified if the tolerance is zero.
+	Tolerance float64
+
+	// Options are passed to NewLayer for each tile.
+	Options []Option
+}
+
+// Generate creates the tiles for all zoom levels, calling fn with the protobuf encoding of each tile that
+// contains at least one feature, including features that are only within the buffer.
+// Tiles are generated depth first, with each tile followed by its children, so that only the records clipped to
+// the ancestors of the current tile are held in memory.
+func (p Pyramid) Generate(records []*shapefile.Record, fn func(Tile, []byte) error) error {
+	if p.MinZoom > p.MaxZoom {
+		return fmt.Errorf("min zoom %d is greater than max zoom %d", p.MinZoom, p.MaxZoom)
+	}
+
+	conf := defaultConfig()
+	for _, opt := range p.Options {
+		opt(&conf)
+	}
+
+	return p.generate(Tile{}, records, conf, fn)
+}
+
+func (p Pyramid) generate(tile Tile, records []*shapefile.Record, conf config, fn func(Tile, []byte) error) error {
+	// Records are clipped with twice the buffer, so that the layer can clip them precisely in tile space
+	box := tile.bufferedBounds(2 * float64(conf.buffer) / float64(conf.extent))
+
+	clipped := make([]*shapefile.Record, 0, len(records))
+	for _, rec := range records {
+		if shape, ok := shp.Clip(rec.Shape, box); ok {
+			clipped = append(clipped, &shapefile.Record{
+				Shape:      shape,
+				Attributes: rec.Attributes,
+			})
+		}
+	}
+
+	if len(clipped) == 0 {
+		return nil
+	}
+
+	if tile.Z >= p.MinZoom {
+		simplifier := shp.Simplifier{
+			Method:    shp.DouglasPeucker,
+			Tolerance: p.Tolerance,
+		}
+		size := float64(uint64(1)<<tile.Z) * float64(conf.extent)
+
+		l := NewLayer(p.Layer, tile, p.Options...)
+		for _, rec := range clipped {
+			shape, ok := simplify(rec.Shape, simplifier, size)
+			if !ok {
+				continue
+			}
+
+			if err := l.Add(&shapefile.Record{
+				Shape:      shape,
+				Attributes: rec.Attributes,
+			}); err != nil {
+				return fmt.Errorf("failed to add record %d to tile %s: %w", rec.Shape.RecordNumber(), tile, err)
+			}
+		}
+
+		if l.Len() > 0 {
+			if err := fn(tile, l.Marshal()); err != nil {
+				return err
+			}
+		}
+	}
+
+	if tile.Z == p.MaxZoom {
+		return nil
+	}
+
+	for _, child := range tile.children() {
+		if err := p.generate(child, clipped, conf, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// simplify simplifies the shape in Web Mercator tile units, where size is the number of units across the world,
+// so that the tolerance is the same in both directions at any latitude.
+// Tile space is flipped vertically so that rings keep their orientation.
+// false is returned if the shape collapses.
+func simplify(shape shp.Shape, s shp.Simplifier, size float64) (shp.Shape, bool) {
+	if _, ok := shape.(shp.Point); ok || s.Tolerance <= 0 {
+		return shape, true
+	}
+
+	projected := shp.TransformShape(shape, func(lng, lat float64) (float64, float64) {
+		x, y := project(lng, lat, 0)
+		return x * size, -y * size
+	})
+
+	simplified, ok := s.Simplify(projected)
+	if !ok {
+		return nil, false
+	}
+
+	return shp.TransformShape(simplified, func(x, y float64) (float64, float64) {
+		return unproject(x/size, -y/size, 0)
+	}), true
+}
@@ -0,0 +1,93 @@
+package mvt_test
+
+import (
+	"testing"
+
+	"github.com/everystreet/go-shapefile"
+	"github.com/everystreet/go-shapefile/mvt"
+	"github.com/everystreet/go-shapefile/shp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPyramid(t *testing.T) {
+	records := []*shapefile.Record{
+		{Shape: shp.MakePoint(-100, 40), Attributes: attrs{}},
+		{Shape: shp.MakePoint(100, -40), Attributes: attrs{}},
+	}
+
+	var tiles []mvt.Tile
+	err := mvt.Pyramid{
+		Layer:   "points",
+		MinZoom: 0,
+		MaxZoom: 2,
+	}.Generate(records, func(tile mvt.Tile, data []byte) error {
+		tiles = append(tiles, tile)
+
+		layer := decode(t, decode(t, data)[3][0].([]byte))
+		require.Equal(t, "points", string(layer[1][0].([]byte)))
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []mvt.Tile{
+		{Z: 0, X: 0, Y: 0},
+		{Z: 1, X: 0, Y: 0},
+		{Z: 2, X: 0, Y: 1},
+		{Z: 1, X: 1, Y: 1},
+		{Z: 2, X: 3, Y: 2},
+	}, tiles)
+
+	square, err := shp.MakePolygon([]shp.Part{{
+		shp.MakePoint(-10, -10),
+		shp.MakePoint(-10, 10),
+		shp.MakePoint(10, 10),
+		shp.MakePoint(10, -10),
+		shp.MakePoint(-10, -10),
+	}})
+	require.NoError(t, err)
+
+	tiles = nil
+	err = mvt.Pyramid{
+		Layer:   "polygons",
+		MinZoom: 1,
+		MaxZoom: 1,
+	}.Generate([]*shapefile.Record{{Shape: square, Attributes: attrs{}}}, func(tile mvt.Tile, data []byte) error {
+		tiles = append(tiles, tile)
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []mvt.Tile{
+		{Z: 1, X: 0, Y: 0},
+		{Z: 1, X: 0, Y: 1},
+		{Z: 1, X: 1, Y: 0},
+		{Z: 1, X: 1, Y: 1},
+	}, tiles)
+
+	err = mvt.Pyramid{MinZoom: 2, MaxZoom: 1}.Generate(records, func(mvt.Tile, []byte) error { return nil })
+	require.Error(t, err)
+}
+
+func TestPyramidTolerance(t *testing.T) {
+	// At 60 degrees north, a tile unit at zoom level 0 is about 0.044 degrees of latitude but 0.088 of longitude
+	line := shp.MakePolyline(shp.Part{
+		shp.MakePoint(0, 60),
+		shp.MakePoint(5, 60.06),
+		shp.MakePoint(10, 60),
+	})
+
+	var geometry []uint64
+	err := mvt.Pyramid{
+		Layer:     "lines",
+		Tolerance: 1,
+	}.Generate([]*shapefile.Record{{Shape: line, Attributes: attrs{}}}, func(tile mvt.Tile, data []byte) error {
+		layer := decode(t, decode(t, data)[3][0].([]byte))
+		feature := decode(t, layer[2][0].([]byte))
+		geometry = packed(t, feature[4][0].([]byte))
+		return nil
+	})
+	require.NoError(t, err)
+
+	// MoveTo, then LineTo with 2 points
+	require.Len(t, geometry, 8)
+}
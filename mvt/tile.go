@@ -0,0 +1,66 @@
+package mvt
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/everystreet/go-shapefile/shp"
+)
+
+// maxLatitude is the latitude at which the Web Mercator projection is clipped, so that the world is square.
+const maxLatitude = 85.05112877980659
+
+// Tile identifies a single tile in the Web Mercator tile pyramid.
+type Tile struct {
+	Z, X, Y uint32
+}
+
+// TileFromPoint returns the tile at zoom level z that contains the longitude and latitude.
+func TileFromPoint(lng, lat float64, z uint32) Tile {
+	x, y := project(lng, lat, z)
+	max := float64(uint32(1)<<z) - 1
+	return Tile{
+		Z: z,
+		X: uint32(math.Max(0, math.Min(max, math.Floor(x)))),
+		Y: uint32(math.Max(0, math.Min(max, math.Floor(y)))),
+	}
+}
+
+// Bounds returns the longitude (X) and latitude (Y) bounds of the tile.
+func (t Tile) Bounds() shp.BoundingBox {
+	return t.bufferedBounds(0)
+}
+
+// bufferedBounds returns the bounds of the tile, expanded on each side by buffer, which is a fraction of the tile size.
+func (t Tile) bufferedBounds(buffer float64) shp.BoundingBox {
+	minX, minY := unproject(float64(t.X)-buffer, float64(t.Y)+1+buffer, t.Z)
+	maxX, maxY := unproject(float64(t.X)+1+buffer, float64(t.Y)-buffer, t.Z)
+	return shp.BoundingBox{MinX: minX, MinY: minY, MaxX: maxX, MaxY: maxY}
+}
+
+// children returns the 4 tiles at the next zoom level that cover the tile, in order of X, then Y.
+func (t Tile) children() []Tile {
+	z, x, y := t.Z+1, t.X*2, t.Y*2
+	return []Tile{{z, x, y}, {z, x, y + 1}, {z, x + 1, y}, {z, x + 1, y + 1}}
+}
+
+func (t Tile) String() string {
+	return fmt.Sprintf("%d/%d/%d", t.Z, t.X, t.Y)
+}
+
+// project converts a longitude and latitude to fractional tile coordinates at zoom level z.
+func project(lng, lat float64, z uint32) (float64, float64) {
+	lat = math.Max(-maxLatitude, math.Min(maxLatitude, lat))
+	n := float64(uint32(1) << z)
+	rad := lat * math.Pi / 180
+
+	x := (lng + 180) / 360 * n
+	y := (1 - math.Log(math.Tan(rad)+1/math.Cos(rad))/math.Pi) / 2 * n
+	return x, y
+}
+
+// unproject converts fractional tile coordinates at zoom level z to a longitude and latitude.
+func unproject(x, y float64, z uint32) (float64, float64) {
+	n := float64(uint32(1) << z)
+	return x/n*360 - 180, math.Atan(math.Sinh(math.Pi*(1-2*y/n))) * 180 / math.Pi
+}
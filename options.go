@@ -2,6 +2,8 @@ package shapefile
 
 import (
 	"github.com/everystreet/go-shapefile/dbf"
+	"github.com/everystreet/go-shapefile/dbf/expr"
+	"github.com/everystreet/go-shapefile/dbf/memo"
 	"github.com/everystreet/go-shapefile/shp"
 	"golang.org/x/text/encoding"
 )
@@ -16,10 +18,69 @@ func PointPrecision(p uint) Option {
 	}
 }
 
+// Transform sets shp.Transform.
+func Transform(fn shp.TransformFunc) Option {
+	return func(o *options) {
+		o.shp = append(o.shp, shp.Transform(fn))
+	}
+}
+
+// Simplification applies the Simplifier to each shape, after any Transform, FilterBox or ClipBox.
+// Records whose shapes collapse entirely are skipped, along with their dbf records.
+func Simplification(s shp.Simplifier) Option {
+	return func(o *options) {
+		o.simplifier = &s
+	}
+}
+
 // CharacterDecoder sets dbf.CharacterDecoder.
+// The encoding identified by the dbf language driver ID, and DetectCharacterEncoding, are ignored.
 func CharacterDecoder(dec *encoding.Decoder) Option {
 	return func(o *options) {
 		o.dbf = append(o.dbf, dbf.CharacterDecoder(dec))
+		o.encoding = ""
+		o.hasEncoding = true
+	}
+}
+
+// DetectCharacterEncoding guesses whether character fields are UTF-8 or use a legacy code page,
+// by sampling the first records of the dbf file. This takes precedence over the language driver ID,
+// and legacy code pages are assumed to be Windows-1252 unless the language driver ID identifies another.
+// It has no effect if an encoding is set by CharacterDecoder or a .cpg file.
+func DetectCharacterEncoding() Option {
+	return func(o *options) {
+		o.detect = true
+	}
+}
+
+// characterEncoding sets a named encoding, such as one specified by a .cpg file.
+func characterEncoding(enc dbf.Encoding) Option {
+	return func(o *options) {
+		o.dbf = append(o.dbf, dbf.CharacterDecoder(enc.NewDecoder()))
+		o.encoding = enc.Name
+		o.hasEncoding = true
+	}
+}
+
+// MemoFile sets dbf.MemoFile.
+// Files opened with Open, OpenPath or an archive scanner use a sibling .dbt or .fpt file automatically.
+func MemoFile(f *memo.File) Option {
+	return func(o *options) {
+		o.dbf = append(o.dbf, dbf.MemoFile(f))
+	}
+}
+
+// InvalidAsNull sets dbf.InvalidAsNull.
+func InvalidAsNull() Option {
+	return func(o *options) {
+		o.dbf = append(o.dbf, dbf.InvalidAsNull())
+	}
+}
+
+// DecimalSeparator sets dbf.DecimalSeparator.
+func DecimalSeparator(sep byte) Option {
+	return func(o *options) {
+		o.dbf = append(o.dbf, dbf.DecimalSeparator(sep))
 	}
 }
 
@@ -27,6 +88,33 @@ func CharacterDecoder(dec *encoding.Decoder) Option {
 func FilterFields(names ...string) Option {
 	return func(o *options) {
 		o.dbf = append(o.dbf, dbf.FilterFields(names...))
+		o.fields = names
+	}
+}
+
+// Where skips records whose attributes don't satisfy the expression, along with their shapes.
+// Fields used by the expression don't need to be included by FilterFields.
+func Where(e *expr.Expr) Option {
+	return func(o *options) {
+		o.where = e
+	}
+}
+
+// FilterBox skips records whose shapes are entirely outside of the box.
+// The dbf record for each skipped shape is skipped too.
+func FilterBox(box shp.BoundingBox) Option {
+	return func(o *options) {
+		o.box = &box
+		o.clip = false
+	}
+}
+
+// ClipBox skips records whose shapes are entirely outside of the box, as FilterBox,
+// and clips the remaining shapes to the box using shp.Clip.
+func ClipBox(box shp.BoundingBox) Option {
+	return func(o *options) {
+		o.box = &box
+		o.clip = true
 	}
 }
 
@@ -34,4 +122,99 @@ func FilterFields(names ...string) Option {
 type options struct {
 	shp []shp.Option
 	dbf []dbf.Option
+
+	box        *shp.BoundingBox
+	clip       bool
+	simplifier *shp.Simplifier
+
+	fields []string
+	where  *expr.Expr
+
+	encoding    string
+	hasEncoding bool
+	detect      bool
+}
+
+// filter applies the bounding box filter and simplification to the shape, returning the shape to use,
+// and false if the record should be skipped.
+func (o options) filter(shape shp.Shape) (shp.Shape, bool) {
+	if o.box != nil {
+		if !shp.BoundingBoxOf(shape).Intersects(*o.box) {
+			return nil, false
+		} else if o.clip {
+			var ok bool
+			if shape, ok = shp.Clip(shape, *o.box); !ok {
+				return nil, false
+			}
+		}
+	}
+
+	if o.simplifier != nil {
+		return o.simplifier.Simplify(shape)
+	}
+	return shape, true
+}
+
+// dbfOptions returns the options for dbf parsing, ensuring that fields used by the Where expression are decoded
+// even if they aren't included by FilterFields.
+func (o options) dbfOptions() []dbf.Option {
+	if o.where == nil || len(o.fields) == 0 {
+		return o.dbf
+	}
+
+	names := append([]string{}, o.fields...)
+	for _, name := range o.where.Fields() {
+		if !contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return append(append([]dbf.Option{}, o.dbf...), dbf.FilterFields(names...))
+}
+
+// match applies the Where expression to the attributes, returning the attributes to use,
+// and false if the record should be skipped.
+func (o options) match(attr *dbf.Record) (Attributes, bool, error) {
+	if o.where == nil {
+		return attr, true, nil
+	}
+
+	ok, err := o.where.Eval(attr)
+	if err != nil || !ok {
+		return nil, false, err
+	} else if len(o.fields) == 0 {
+		return attr, true, nil
+	}
+	return selectedAttributes{attr, o.fields}, true, nil
+}
+
+// selectedAttributes hides fields that were only decoded to evaluate a Where expression.
+type selectedAttributes struct {
+	*dbf.Record
+	names []string
+}
+
+func (a selectedAttributes) Fields() []dbf.Field {
+	var out []dbf.Field
+	for _, f := range a.Record.Fields() {
+		if contains(a.names, f.Name()) {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+func (a selectedAttributes) Field(name string) (dbf.Field, bool) {
+	if !contains(a.names, name) {
+		return nil, false
+	}
+	return a.Record.Field(name)
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
 }
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"text/tabwriter"
 
+	"github.com/everystreet/go-shapefile/dbf"
 	"github.com/olekukonko/tablewriter"
 )
 
@@ -145,18 +146,17 @@ func (p TablePrinter) row(rec *Record) ([]string, error) {
 		row = append(row, make([]string, len(info.Fields))...)
 		for i, field := range info.Fields {
 			if f, ok := rec.Attributes.Field(field.Name()); ok {
-				row[i+1] = fmt.Sprintf("%v", f.Value())
+				row[i+1] = dbf.FormatValue(f.Value())
 			}
 		}
 		return row, nil
 	}
 
 	// ...or just the specified fields
+	row = append(row, make([]string, len(p.fields))...)
 	for i, name := range p.fields {
-		row = append(row, make([]string, len(p.fields))...)
-
 		if f, ok := rec.Attributes.Field(name); ok {
-			row[i+1] = fmt.Sprintf("%v", f.Value())
+			row[i+1] = dbf.FormatValue(f.Value())
 		}
 	}
 	return row, nil
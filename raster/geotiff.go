@@ -0,0 +1,179 @@
+package raster
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// TIFF field types.
+const (
+	tiffASCII  uint16 = 2
+	tiffShort  uint16 = 3
+	tiffLong   uint16 = 4
+	tiffDouble uint16 = 12
+)
+
+type tiffEntry struct {
+	tag   uint16
+	typ   uint16
+	count uint32
+	data  []byte
+}
+
+// WriteGeoTIFF writes the grid as an uncompressed, single-band GeoTIFF of 64-bit floating point values.
+// The grid is georeferenced with a tie point at the top-left corner and the cell size,
+// and the no-data value is recorded in the GDAL_NODATA tag.
+// If the grid has an EPSG code, it's recorded as a geographic CRS if it's in the range 4000-4999,
+// or as a projected CRS otherwise.
+// An error is returned if the file would be larger than the 4 GiB limit of a classic TIFF.
+func (g *Grid) WriteGeoTIFF(w io.Writer) error {
+	size := uint64(g.Width) * uint64(g.Height) * 8
+	if size > math.MaxUint32 {
+		return fmt.Errorf("grid of %dx%d cells is too large for a TIFF", g.Width, g.Height)
+	}
+	dataLen := uint32(size)
+
+	entries := []tiffEntry{
+		longEntry(256, uint32(g.Width)),
+		longEntry(257, uint32(g.Height)),
+		shortEntry(258, 64),
+		shortEntry(259, 1), // No compression
+		shortEntry(262, 1), // Black is zero
+		longEntry(273, 0),  // Strip offset, set below
+		shortEntry(277, 1),
+		longEntry(278, uint32(g.Height)),
+		longEntry(279, dataLen),
+		shortEntry(284, 1), // Chunky
+		shortEntry(339, 3), // IEEE floating point
+		doubleEntry(33550, g.CellSize, g.CellSize, 0),
+		doubleEntry(33922, 0, 0, 0, g.Extent.MinX, g.Extent.MaxY, 0),
+		shortEntry(34735, g.geoKeys()...),
+		asciiEntry(42113, strconv.FormatFloat(g.NoData, 'f', -1, 64)),
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].tag < entries[j].tag
+	})
+
+	// Values that don't fit in an entry are written after the directory
+	offset := uint32(8 + 2 + len(entries)*12 + 4)
+	var extra []byte
+	offsets := make([]uint32, len(entries))
+	for i, e := range entries {
+		if len(e.data) > 4 {
+			offsets[i] = offset + uint32(len(extra))
+			extra = append(extra, e.data...)
+			if len(extra)%2 != 0 {
+				extra = append(extra, 0)
+			}
+		}
+	}
+
+	if uint64(offset)+uint64(len(extra))+size > math.MaxUint32 {
+		return fmt.Errorf("grid of %dx%d cells is too large for a TIFF", g.Width, g.Height)
+	}
+
+	for i, e := range entries {
+		if e.tag == 273 {
+			binary.LittleEndian.PutUint32(entries[i].data, offset+uint32(len(extra)))
+		}
+	}
+
+	out := bufio.NewWriter(w)
+	header := make([]byte, 8)
+	copy(header, "II")
+	binary.LittleEndian.PutUint16(header[2:4], 42)
+	binary.LittleEndian.PutUint32(header[4:8], 8)
+	out.Write(header)
+
+	ifd := make([]byte, 2, 2+len(entries)*12+4)
+	binary.LittleEndian.PutUint16(ifd, uint16(len(entries)))
+	for i, e := range entries {
+		buf := make([]byte, 12)
+		binary.LittleEndian.PutUint16(buf[0:2], e.tag)
+		binary.LittleEndian.PutUint16(buf[2:4], e.typ)
+		binary.LittleEndian.PutUint32(buf[4:8], e.count)
+		if len(e.data) > 4 {
+			binary.LittleEndian.PutUint32(buf[8:12], offsets[i])
+		} else {
+			copy(buf[8:12], e.data)
+		}
+		ifd = append(ifd, buf...)
+	}
+	ifd = append(ifd, 0, 0, 0, 0) // No more directories
+	out.Write(ifd)
+	out.Write(extra)
+
+	buf := make([]byte, 8)
+	for _, v := range g.Values {
+		binary.LittleEndian.PutUint64(buf, math.Float64bits(v))
+		out.Write(buf)
+	}
+	return out.Flush()
+}
+
+// geoKeys returns the GeoKeyDirectoryTag values.
+func (g *Grid) geoKeys() []uint16 {
+	const (
+		modelTypeKey    = 1024
+		rasterTypeKey   = 1025
+		geographicKey   = 2048
+		projectedKey    = 3072
+		userDefined     = 32767
+		pixelIsArea     = 1
+		modelProjected  = 1
+		modelGeographic = 2
+		geographicMin   = 4000
+		geographicMax   = 4999
+	)
+
+	modelType, crsKey := uint16(userDefined), uint16(0)
+	switch {
+	case g.EPSG >= geographicMin && g.EPSG <= geographicMax:
+		modelType, crsKey = modelGeographic, geographicKey
+	case g.EPSG != 0:
+		modelType, crsKey = modelProjected, projectedKey
+	}
+
+	keys := []uint16{
+		modelTypeKey, 0, 1, modelType,
+		rasterTypeKey, 0, 1, pixelIsArea,
+	}
+	if crsKey != 0 {
+		keys = append(keys, crsKey, 0, 1, g.EPSG)
+	}
+
+	// Version 1.1.0, followed by the number of keys
+	return append([]uint16{1, 1, 0, uint16(len(keys) / 4)}, keys...)
+}
+
+func shortEntry(tag uint16, values ...uint16) tiffEntry {
+	data := make([]byte, len(values)*2)
+	for i, v := range values {
+		binary.LittleEndian.PutUint16(data[i*2:], v)
+	}
+	return tiffEntry{tag: tag, typ: tiffShort, count: uint32(len(values)), data: data}
+}
+
+func longEntry(tag uint16, v uint32) tiffEntry {
+	data := make([]byte, 4)
+	binary.LittleEndian.PutUint32(data, v)
+	return tiffEntry{tag: tag, typ: tiffLong, count: 1, data: data}
+}
+
+func doubleEntry(tag uint16, values ...float64) tiffEntry {
+	data := make([]byte, len(values)*8)
+	for i, v := range values {
+		binary.LittleEndian.PutUint64(data[i*8:], math.Float64bits(v))
+	}
+	return tiffEntry{tag: tag, typ: tiffDouble, count: uint32(len(values)), data: data}
+}
+
+func asciiEntry(tag uint16, s string) tiffEntry {
+	data := append([]byte(s), 0)
+	return tiffEntry{tag: tag, typ: tiffASCII, count: uint32(len(data)), data: data}
+}
@@ -0,0 +1,263 @@
+// Package raster converts shapefiles to grids of values, which can be written as PNG or GeoTIFF images.
+package raster
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/everystreet/go-shapefile"
+	"github.com/everystreet/go-shapefile/shp"
+)
+
+// Grid is a georeferenced raster of values, stored in rows from top to bottom.
+type Grid struct {
+	// Extent is the area covered by the grid, where MinX, MaxY is the top-left corner.
+	Extent   shp.BoundingBox
+	CellSize float64
+
+	Width, Height int
+	Values        []float64
+	NoData        float64
+
+	// EPSG is the code of the coordinate reference system, or 0 if it isn't known.
+	EPSG uint16
+}
+
+// At returns the value of the cell in the specified column and row.
+func (g *Grid) At(col, row int) float64 {
+	return g.Values[row*g.Width+col]
+}
+
+func (g *Grid) set(col, row int, v float64) {
+	if col >= 0 && col < g.Width && row >= 0 && row < g.Height {
+		g.Values[row*g.Width+col] = v
+	}
+}
+
+// Rasterize draws every record from the scanner onto a grid, where later records overwrite earlier ones.
+// Polygons are filled using the even-odd rule, so holes are left empty.
+func Rasterize(s shapefile.Scannable, opts ...Option) (*Grid, error) {
+	conf := defaultConfig()
+	for _, opt := range opts {
+		opt(&conf)
+	}
+
+	if conf.cellSize <= 0 {
+		return nil, fmt.Errorf("cell size must be positive")
+	}
+
+	if err := s.Scan(); err != nil {
+		return nil, err
+	}
+
+	extent := conf.extent
+	if extent == nil {
+		info, err := s.Info()
+		if err != nil {
+			return nil, err
+		}
+		extent = &info.BoundingBox
+	}
+
+	g := newGrid(*extent, conf)
+	for {
+		rec := s.Record()
+		if rec == nil {
+			break
+		}
+
+		value := 1.0
+		if conf.field != "" {
+			if rec.Attributes == nil {
+				continue
+			}
+
+			f, ok := rec.Field(conf.field)
+			if !ok {
+				continue
+			} else if value, ok = f.Value().(float64); !ok {
+				continue
+			}
+		}
+
+		switch shape := rec.Shape.(type) {
+		case shp.Point:
+			col, row := g.cell(shape)
+			g.set(int(math.Floor(col)), int(math.Floor(row)), value)
+		case shp.Polyline:
+			g.lines(shape.Parts, value, conf.allTouched)
+		case shp.Polygon:
+			g.fill(shape.Parts, value)
+			if conf.allTouched {
+				g.lines(shape.Parts, value, true)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported shape type %s", rec.Shape.Type())
+		}
+	}
+
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func newGrid(extent shp.BoundingBox, conf config) *Grid {
+	width := int(math.Max(1, math.Ceil((extent.MaxX-extent.MinX)/conf.cellSize)))
+	height := int(math.Max(1, math.Ceil((extent.MaxY-extent.MinY)/conf.cellSize)))
+
+	values := make([]float64, width*height)
+	for i := range values {
+		values[i] = conf.noData
+	}
+
+	return &Grid{
+		Extent: shp.BoundingBox{
+			MinX: extent.MinX,
+			MinY: extent.MaxY - float64(height)*conf.cellSize,
+			MaxX: extent.MinX + float64(width)*conf.cellSize,
+			MaxY: extent.MaxY,
+		},
+		CellSize: conf.cellSize,
+		Width:    width,
+		Height:   height,
+		Values:   values,
+		NoData:   conf.noData,
+		EPSG:     conf.epsg,
+	}
+}
+
+// cell returns the fractional column and row of the point.
+func (g *Grid) cell(p shp.Point) (float64, float64) {
+	return (p.X - g.Extent.MinX) / g.CellSize, (g.Extent.MaxY - p.Y) / g.CellSize
+}
+
+// fill sets every cell whose centre is inside the rings.
+func (g *Grid) fill(parts []shp.Part, value float64) {
+	minY, maxY := math.Inf(1), math.Inf(-1)
+	for _, part := range parts {
+		for _, p := range part {
+			minY, maxY = math.Min(minY, p.Y), math.Max(maxY, p.Y)
+		}
+	}
+
+	if minY > maxY {
+		return
+	}
+
+	// Only rows whose centres are within the bounds of the rings
+	firstRow := int(math.Max(0, math.Ceil((g.Extent.MaxY-maxY)/g.CellSize-0.5)))
+	lastRow := int(math.Min(float64(g.Height-1), math.Floor((g.Extent.MaxY-minY)/g.CellSize-0.5)))
+	for row := firstRow; row <= lastRow; row++ {
+		y := g.Extent.MaxY - (float64(row)+0.5)*g.CellSize
+
+		var xs []float64
+		for _, part := range parts {
+			for i, j := 0, len(part)-1; i < len(part); j, i = i, i+1 {
+				a, b := part[i], part[j]
+				if (a.Y > y) != (b.Y > y) {
+					xs = append(xs, (b.X-a.X)*(y-a.Y)/(b.Y-a.Y)+a.X)
+				}
+			}
+		}
+		sort.Float64s(xs)
+
+		for i := 0; i+1 < len(xs); i += 2 {
+			// Columns whose centres are within the span
+			first := int(math.Max(0, math.Ceil((xs[i]-g.Extent.MinX)/g.CellSize-0.5)))
+			last := int(math.Min(float64(g.Width), math.Ceil((xs[i+1]-g.Extent.MinX)/g.CellSize-0.5))) - 1
+			for col := first; col <= last; col++ {
+				g.set(col, row, value)
+			}
+		}
+	}
+}
+
+// lines sets the cells along each part. If allTouched is true, every cell that a segment passes through is set,
+// otherwise a thinner line is drawn with Bresenham's algorithm.
+func (g *Grid) lines(parts []shp.Part, value float64, allTouched bool) {
+	for _, part := range parts {
+		for i := 1; i < len(part); i++ {
+			x0, y0 := g.cell(part[i-1])
+			x1, y1 := g.cell(part[i])
+			if allTouched {
+				g.traverse(x0, y0, x1, y1, value)
+			} else {
+				g.bresenham(int(math.Floor(x0)), int(math.Floor(y0)), int(math.Floor(x1)), int(math.Floor(y1)), value)
+			}
+		}
+
+		if len(part) == 1 {
+			col, row := g.cell(part[0])
+			g.set(int(math.Floor(col)), int(math.Floor(row)), value)
+		}
+	}
+}
+
+// traverse sets every cell that the segment passes through, using the Amanatides-Woo algorithm.
+func (g *Grid) traverse(x0, y0, x1, y1, value float64) {
+	col, row := int(math.Floor(x0)), int(math.Floor(y0))
+	endCol, endRow := int(math.Floor(x1)), int(math.Floor(y1))
+
+	step := func(d, start float64, cell int) (int, float64, float64) {
+		switch {
+		case d > 0:
+			return 1, (float64(cell+1) - start) / d, 1 / d
+		case d < 0:
+			return -1, (start - float64(cell)) / -d, -1 / d
+		default:
+			return 0, math.Inf(1), math.Inf(1)
+		}
+	}
+	stepX, maxX, deltaX := step(x1-x0, x0, col)
+	stepY, maxY, deltaY := step(y1-y0, y0, row)
+
+	g.set(col, row, value)
+	for n := absInt(endCol-col) + absInt(endRow-row); n > 0; n-- {
+		if maxX < maxY {
+			maxX += deltaX
+			col += stepX
+		} else {
+			maxY += deltaY
+			row += stepY
+		}
+		g.set(col, row, value)
+	}
+}
+
+func (g *Grid) bresenham(x0, y0, x1, y1 int, value float64) {
+	dx, dy := absInt(x1-x0), -absInt(y1-y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+
+	err := dx + dy
+	for {
+		g.set(x0, y0, value)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+func absInt(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
@@ -0,0 +1,68 @@
+package raster
+
+import "github.com/everystreet/go-shapefile/shp"
+
+// Option funcs can be passed to Rasterize().
+type Option func(*config)
+
+// CellSize sets the width and height of each cell, in the units of the shape coordinates. The default is 1.
+func CellSize(size float64) Option {
+	return func(c *config) {
+		c.cellSize = size
+	}
+}
+
+// Extent sets the area covered by the grid. By default, the bounding box of the shapefile is used.
+// The extent is grown to the right and downwards so that it's a whole number of cells.
+func Extent(box shp.BoundingBox) Option {
+	return func(c *config) {
+		c.extent = &box
+	}
+}
+
+// AllTouched sets every cell that a shape touches, rather than only those whose centre is inside the shape.
+func AllTouched() Option {
+	return func(c *config) {
+		c.allTouched = true
+	}
+}
+
+// ValueField sets the numeric field whose value is stored in each cell.
+// Records without a numeric value for the field are skipped.
+// By default, every cell covered by a shape is set to 1.
+func ValueField(name string) Option {
+	return func(c *config) {
+		c.field = name
+	}
+}
+
+// NoData sets the value of cells that aren't covered by any shape. The default is -9999.
+func NoData(v float64) Option {
+	return func(c *config) {
+		c.noData = v
+	}
+}
+
+// EPSG sets the EPSG code of the coordinate reference system, which is recorded in GeoTIFF output.
+func EPSG(code uint16) Option {
+	return func(c *config) {
+		c.epsg = code
+	}
+}
+
+// Config for rasterizing.
+type config struct {
+	cellSize   float64
+	extent     *shp.BoundingBox
+	allTouched bool
+	field      string
+	noData     float64
+	epsg       uint16
+}
+
+func defaultConfig() config {
+	return config{
+		cellSize: 1,
+		noData:   -9999,
+	}
+}
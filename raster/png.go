@@ -0,0 +1,43 @@
+package raster
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+)
+
+// Image returns a greyscale preview of the grid, where the highest value is black, the lowest value is light grey,
+// and cells with no data are transparent.
+func (g *Grid) Image() image.Image {
+	min, max := math.Inf(1), math.Inf(-1)
+	for _, v := range g.Values {
+		if v != g.NoData {
+			min, max = math.Min(min, v), math.Max(max, v)
+		}
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, g.Width, g.Height))
+	for row := 0; row < g.Height; row++ {
+		for col := 0; col < g.Width; col++ {
+			v := g.At(col, row)
+			if v == g.NoData {
+				continue
+			}
+
+			shade := 0.0
+			if max > min {
+				shade = (max - v) / (max - min) * 200
+			}
+			grey := uint8(math.Round(shade))
+			img.SetNRGBA(col, row, color.NRGBA{R: grey, G: grey, B: grey, A: 255})
+		}
+	}
+	return img
+}
+
+// WritePNG writes the preview image returned by Image as a PNG.
+func (g *Grid) WritePNG(w io.Writer) error {
+	return png.Encode(w, g.Image())
+}
@@ -0,0 +1,164 @@
+package raster_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image/png"
+	"math"
+	"testing"
+
+	"github.com/everystreet/go-shapefile"
+	"github.com/everystreet/go-shapefile/raster"
+	"github.com/everystreet/go-shapefile/shp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRasterize(t *testing.T) {
+	// A diamond that covers the centres of the 4 middle cells, and touches all but the 4 corner cells
+	diamond, err := shp.MakePolygon([]shp.Part{{
+		shp.MakePoint(2, 0.2),
+		shp.MakePoint(0.2, 2),
+		shp.MakePoint(2, 3.8),
+		shp.MakePoint(3.8, 2),
+		shp.MakePoint(2, 0.2),
+	}})
+	require.NoError(t, err)
+
+	box := shp.BoundingBox{MinX: 0, MinY: 0, MaxX: 4, MaxY: 4}
+
+	t.Run("centre", func(t *testing.T) {
+		g, err := raster.Rasterize(&scanner{box: box, shapes: []shp.Shape{diamond}})
+		require.NoError(t, err)
+		require.Equal(t, 4, g.Width)
+		require.Equal(t, 4, g.Height)
+		require.Equal(t, 4, count(g))
+	})
+
+	t.Run("all touched", func(t *testing.T) {
+		g, err := raster.Rasterize(&scanner{box: box, shapes: []shp.Shape{diamond}}, raster.AllTouched())
+		require.NoError(t, err)
+		require.Equal(t, 12, count(g))
+	})
+
+	t.Run("cell size and extent", func(t *testing.T) {
+		g, err := raster.Rasterize(&scanner{box: box, shapes: []shp.Shape{shp.MakePoint(1.5, 2.5)}},
+			raster.CellSize(0.5),
+			raster.Extent(shp.BoundingBox{MinX: 1, MinY: 1, MaxX: 3.2, MaxY: 3}),
+		)
+		require.NoError(t, err)
+		require.Equal(t, 5, g.Width)
+		require.Equal(t, 4, g.Height)
+		require.Equal(t, 3.5, g.Extent.MaxX)
+		require.Equal(t, 1.0, g.At(1, 1))
+		require.Equal(t, 1, count(g))
+	})
+
+	t.Run("polygon beyond extent", func(t *testing.T) {
+		g, err := raster.Rasterize(&scanner{box: box, shapes: []shp.Shape{diamond}},
+			raster.Extent(shp.BoundingBox{MinX: 1, MinY: 1, MaxX: 3, MaxY: 3}),
+		)
+		require.NoError(t, err)
+		require.Equal(t, 2, g.Width)
+		require.Equal(t, 2, g.Height)
+		require.Equal(t, 4, count(g))
+	})
+
+	t.Run("line", func(t *testing.T) {
+		line := shp.MakePolyline(shp.Part{shp.MakePoint(0.5, 0.5), shp.MakePoint(3.5, 3.5)})
+		g, err := raster.Rasterize(&scanner{box: box, shapes: []shp.Shape{line}})
+		require.NoError(t, err)
+		require.Equal(t, 4, count(g))
+		for i := 0; i < 4; i++ {
+			require.Equal(t, 1.0, g.At(i, 3-i))
+		}
+	})
+}
+
+func TestGridOutput(t *testing.T) {
+	g, err := raster.Rasterize(&scanner{
+		box:    shp.BoundingBox{MinX: 10, MinY: 20, MaxX: 13, MaxY: 22},
+		shapes: []shp.Shape{shp.MakePoint(10.5, 21.5), shp.MakePoint(12.5, 20.5)},
+	}, raster.EPSG(4326))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, g.WritePNG(&buf))
+	img, err := png.Decode(&buf)
+	require.NoError(t, err)
+	require.Equal(t, 3, img.Bounds().Dx())
+	require.Equal(t, 2, img.Bounds().Dy())
+
+	buf.Reset()
+	require.NoError(t, g.WriteGeoTIFF(&buf))
+	tiff := buf.Bytes()
+	require.Equal(t, "II", string(tiff[0:2]))
+
+	tags := make(map[uint16][]byte)
+	ifd := binary.LittleEndian.Uint32(tiff[4:8])
+	n := binary.LittleEndian.Uint16(tiff[ifd:])
+	for i := uint32(0); i < uint32(n); i++ {
+		entry := tiff[ifd+2+i*12:]
+		tags[binary.LittleEndian.Uint16(entry[0:2])] = entry[4:12]
+	}
+
+	require.Equal(t, uint32(3), binary.LittleEndian.Uint32(tags[256][4:8]))
+	require.Equal(t, uint32(2), binary.LittleEndian.Uint32(tags[257][4:8]))
+
+	tiepoint := binary.LittleEndian.Uint32(tags[33922][4:8])
+	require.Equal(t, 10.0, math.Float64frombits(binary.LittleEndian.Uint64(tiff[tiepoint+24:])))
+	require.Equal(t, 22.0, math.Float64frombits(binary.LittleEndian.Uint64(tiff[tiepoint+32:])))
+
+	keys := binary.LittleEndian.Uint32(tags[34735][4:8])
+	require.Equal(t, uint16(3), binary.LittleEndian.Uint16(tiff[keys+6:]))
+	require.Equal(t, uint16(4326), binary.LittleEndian.Uint16(tiff[keys+30:]))
+
+	data := binary.LittleEndian.Uint32(tags[273][4:8])
+	values := make([]float64, 6)
+	for i := range values {
+		values[i] = math.Float64frombits(binary.LittleEndian.Uint64(tiff[data+uint32(i*8):]))
+	}
+	require.Equal(t, []float64{1, -9999, -9999, -9999, -9999, 1}, values)
+
+	// 2^16 x 2^13 cells of 8 bytes is exactly 4 GiB, so the values aren't needed to fail
+	require.Error(t, (&raster.Grid{Width: 1 << 16, Height: 1 << 13}).WriteGeoTIFF(&buf))
+}
+
+func count(g *raster.Grid) int {
+	var n int
+	for _, v := range g.Values {
+		if v != g.NoData {
+			n++
+		}
+	}
+	return n
+}
+
+// scanner provides shapes without attributes.
+type scanner struct {
+	box    shp.BoundingBox
+	shapes []shp.Shape
+}
+
+func (s *scanner) AddOptions(...shapefile.Option) {}
+
+func (s *scanner) Info() (*shapefile.Info, error) {
+	return &shapefile.Info{BoundingBox: s.box, NumRecords: uint32(len(s.shapes))}, nil
+}
+
+func (s *scanner) Scan() error {
+	return nil
+}
+
+func (s *scanner) Record() *shapefile.Record {
+	if len(s.shapes) == 0 {
+		return nil
+	}
+
+	rec := &shapefile.Record{Shape: s.shapes[0]}
+	s.shapes = s.shapes[1:]
+	return rec
+}
+
+func (s *scanner) Err() error {
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/everystreet/go-geojson/v2"
 	"github.com/everystreet/go-shapefile/dbf"
 	"github.com/everystreet/go-shapefile/shp"
+	"github.com/golang/geo/s2"
 )
 
 // Record consists of a shape (read from the .shp file) and attributes (from the .dbf file).
@@ -46,6 +47,12 @@ func (r Record) GeoJSONFeature(opts ...GeoJSONOption) *geojson.Feature {
 	return feat
 }
 
+// S2Covering returns the S2 cells that cover the record's shape.
+// If coverer is nil, s2.NewRegionCoverer is used.
+func (r Record) S2Covering(coverer *s2.RegionCoverer) s2.CellUnion {
+	return shp.Covering(r.Shape, coverer)
+}
+
 // GeoJSONOption funcs can be passed to Record.GeoJSONFeature().
 type GeoJSONOption func(*geoJSONConfig)
 
@@ -0,0 +1,83 @@
+package render
+
+// Option funcs can be passed to SVG().
+type Option func(*config)
+
+// Width sets the width of the SVG document in pixels. The height is chosen to match the bounding box.
+// The default is 1024.
+func Width(w int) Option {
+	return func(c *config) {
+		c.width = w
+	}
+}
+
+// Margin sets the space in pixels between the shapes and the edge of the document. The default is 10.
+func Margin(m int) Option {
+	return func(c *config) {
+		c.margin = m
+	}
+}
+
+// Fill sets the default fill colour of polygons and points, which is used if no rule matches a record.
+// Any CSS colour can be used. The default is "lightgrey".
+func Fill(color string) Option {
+	return func(c *config) {
+		c.fill = color
+	}
+}
+
+// Stroke sets the colour and width of polygon outlines, polylines and point outlines.
+// The default is a "black" stroke of width 1.
+func Stroke(color string, width float64) Option {
+	return func(c *config) {
+		c.stroke = color
+		c.strokeWidth = width
+	}
+}
+
+// PointRadius sets the radius in pixels of the circle drawn for each point. The default is 3.
+func PointRadius(r int) Option {
+	return func(c *config) {
+		c.pointRadius = r
+	}
+}
+
+// FillRule sets a rule that chooses the fill colour of each record from its attributes.
+func FillRule(r Rule) Option {
+	return func(c *config) {
+		c.rule = r
+	}
+}
+
+// Labels draws the value of the named field at an interior point of each shape.
+func Labels(field string, fontSize int) Option {
+	return func(c *config) {
+		c.labelField = field
+		c.fontSize = fontSize
+	}
+}
+
+// Config for rendering.
+type config struct {
+	width       int
+	margin      int
+	fill        string
+	stroke      string
+	strokeWidth float64
+	pointRadius int
+	rule        Rule
+	labelField  string
+	fontSize    int
+}
+
+func defaultConfig() config {
+	return config{
+		width:       1024,
+		margin:      10,
+		fill:        "lightgrey",
+		stroke:      "black",
+		strokeWidth: 1,
+		pointRadius: 3,
+		fontSize:    10,
+	}
+}
@@ -0,0 +1,169 @@
+// Package render draws shapefiles as SVG documents.
+package render
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	svg "github.com/ajstarks/svgo"
+	"github.com/everystreet/go-shapefile"
+	"github.com/everystreet/go-shapefile/shp"
+)
+
+// SVG draws every record from the scanner, fitted to the bounding box of the shapefile.
+// X coordinates increase to the right and Y coordinates increase upwards, with no other projection.
+// Polygons are filled using the even-odd rule so that holes are left empty.
+func SVG(w io.Writer, s shapefile.Scannable, opts ...Option) error {
+	conf := defaultConfig()
+	for _, opt := range opts {
+		opt(&conf)
+	}
+
+	if err := s.Scan(); err != nil {
+		return err
+	}
+
+	info, err := s.Info()
+	if err != nil {
+		return err
+	}
+
+	t := newTransform(info.BoundingBox, conf.width, conf.margin)
+
+	canvas := svg.New(w)
+	canvas.Start(conf.width, t.height)
+	canvas.Group(fmt.Sprintf("stroke:%s;stroke-width:%s;stroke-linejoin:round",
+		escape(conf.stroke), formatFloat(conf.strokeWidth)))
+
+	type label struct {
+		x, y int
+		text string
+	}
+	var labels []label
+
+	for {
+		rec := s.Record()
+		if rec == nil {
+			break
+		}
+
+		fill := conf.fill
+		if conf.rule != nil {
+			if color, ok := conf.rule.Color(rec); ok {
+				fill = color
+			}
+		}
+
+		var interior shp.Point
+		switch shape := rec.Shape.(type) {
+		case shp.Point:
+			x, y := t.apply(shape)
+			canvas.Circle(int(math.Round(x)), int(math.Round(y)), conf.pointRadius, "fill:"+escape(fill))
+			interior = shape
+		case shp.Polyline:
+			canvas.Path(t.path(shape.Parts, false), "fill:none")
+			interior = shape.InteriorPoint()
+		case shp.Polygon:
+			canvas.Path(t.path(shape.Parts, true), "fill:"+escape(fill)+";fill-rule:evenodd")
+			interior = shape.InteriorPoint()
+		default:
+			return fmt.Errorf("unsupported shape type %s", rec.Shape.Type())
+		}
+
+		if conf.labelField == "" || rec.Attributes == nil {
+			continue
+		} else if field, ok := rec.Field(conf.labelField); ok && field.Value() != nil {
+			x, y := t.apply(interior)
+			labels = append(labels, label{
+				x:    int(math.Round(x)),
+				y:    int(math.Round(y)),
+				text: fmt.Sprint(field.Value()),
+			})
+		}
+	}
+
+	if err := s.Err(); err != nil {
+		return err
+	}
+	canvas.Gend()
+
+	// Labels are drawn last so that they aren't hidden by other shapes
+	if len(labels) > 0 {
+		canvas.Group(fmt.Sprintf("font-family:sans-serif;font-size:%dpx;text-anchor:middle", conf.fontSize))
+		for _, l := range labels {
+			canvas.Text(l.x, l.y, l.text)
+		}
+		canvas.Gend()
+	}
+
+	canvas.End()
+	return nil
+}
+
+// transform converts shape coordinates to pixels.
+type transform struct {
+	box    shp.BoundingBox
+	scale  float64
+	margin float64
+	height int
+}
+
+func newTransform(box shp.BoundingBox, width, margin int) transform {
+	// Avoid dividing by zero for a single point or a horizontal or vertical line
+	dx, dy := box.MaxX-box.MinX, box.MaxY-box.MinY
+	if dx == 0 && dy == 0 {
+		box.MinX, box.MaxX, box.MinY, box.MaxY = box.MinX-1, box.MaxX+1, box.MinY-1, box.MaxY+1
+		dx, dy = 2, 2
+	} else if dx == 0 {
+		box.MinX, box.MaxX = box.MinX-dy/2, box.MaxX+dy/2
+		dx = dy
+	}
+
+	inner := math.Max(1, float64(width-2*margin))
+	scale := inner / dx
+	return transform{
+		box:    box,
+		scale:  scale,
+		margin: float64(margin),
+		height: int(math.Ceil(dy*scale)) + 2*margin,
+	}
+}
+
+func (t transform) apply(p shp.Point) (float64, float64) {
+	return t.margin + (p.X-t.box.MinX)*t.scale, t.margin + (t.box.MaxY-p.Y)*t.scale
+}
+
+// path creates SVG path data for the parts, optionally closing each one.
+func (t transform) path(parts []shp.Part, closed bool) string {
+	var b strings.Builder
+	for _, part := range parts {
+		for i, p := range part {
+			x, y := t.apply(p)
+			if i == 0 {
+				b.WriteByte('M')
+			} else {
+				b.WriteByte('L')
+			}
+			b.WriteString(formatFloat(x))
+			b.WriteByte(' ')
+			b.WriteString(formatFloat(y))
+		}
+
+		if closed && len(part) > 0 {
+			b.WriteByte('Z')
+		}
+	}
+	return b.String()
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(math.Round(f*100)/100, 'f', -1, 64)
+}
+
+func escape(s string) string {
+	return html.EscapeString(s)
+}
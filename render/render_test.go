@@ -0,0 +1,120 @@
+package render_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/everystreet/go-shapefile"
+	"github.com/everystreet/go-shapefile/dbf"
+	"github.com/everystreet/go-shapefile/render"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSVG(t *testing.T) {
+	f, err := shapefile.OpenPath(filepath.Join("..", "testdata", "ne_110m_admin_0_sovereignty.shp"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	var buf bytes.Buffer
+	require.NoError(t, render.SVG(&buf, f,
+		render.Width(720),
+		render.FillRule(render.Categorized{
+			Field: "CONTINENT",
+			Colors: map[string]string{
+				"Africa": "#ff0000",
+				"Europe": "#0000ff",
+			},
+		}),
+		render.Labels("NAME", 8),
+	))
+
+	var doc struct {
+		Width  int `xml:"width,attr"`
+		Height int `xml:"height,attr"`
+		Groups []struct {
+			Paths []struct {
+				Style string `xml:"style,attr"`
+			} `xml:"path"`
+			Text []string `xml:"text"`
+		} `xml:"g"`
+	}
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+
+	info, err := f.Info()
+	require.NoError(t, err)
+
+	// The height matches the aspect ratio of the bounding box, plus the margins
+	box := info.BoundingBox
+	require.Equal(t, 720, doc.Width)
+	require.InDelta(t, (box.MaxY-box.MinY)/(box.MaxX-box.MinX)*700+20, doc.Height, 1)
+
+	require.Len(t, doc.Groups, 2)
+	require.Len(t, doc.Groups[0].Paths, int(info.NumRecords))
+
+	fills := make(map[string]int)
+	for _, p := range doc.Groups[0].Paths {
+		fills[strings.Split(p.Style, ";")[0]]++
+	}
+	require.Contains(t, fills, "fill:#ff0000")
+	require.Contains(t, fills, "fill:#0000ff")
+	require.Contains(t, fills, "fill:lightgrey")
+
+	require.Contains(t, doc.Groups[1].Text, "France")
+}
+
+func TestGraduated(t *testing.T) {
+	rule := render.Graduated{
+		Field:  "POP",
+		Breaks: []float64{10, 100},
+		Colors: []string{"a", "b", "c"},
+	}
+
+	for value, expected := range map[float64]string{5: "a", 10: "a", 50: "b", 1000: "c"} {
+		color, ok := rule.Color(&shapefile.Record{Attributes: attrs{"POP": value}})
+		require.True(t, ok)
+		require.Equal(t, expected, color)
+	}
+
+	_, ok := rule.Color(&shapefile.Record{Attributes: attrs{"POP": "many"}})
+	require.False(t, ok)
+}
+
+type attrs map[string]interface{}
+
+func (a attrs) Fields() []dbf.Field {
+	var out []dbf.Field
+	for name, value := range a {
+		out = append(out, field{name, value})
+	}
+	return out
+}
+
+func (a attrs) Field(name string) (dbf.Field, bool) {
+	value, ok := a[name]
+	return field{name, value}, ok
+}
+
+func (a attrs) Deleted() bool {
+	return false
+}
+
+type field struct {
+	name  string
+	value interface{}
+}
+
+func (f field) Name() string {
+	return f.name
+}
+
+func (f field) Value() interface{} {
+	return f.value
+}
+
+func (f field) Equal(v string) bool {
+	return fmt.Sprint(f.value) == v
+}
@@ -0,0 +1,80 @@
+package render
+
+import (
+	"fmt"
+
+	"github.com/everystreet/go-shapefile"
+)
+
+// Rule chooses a colour for a record based on its attributes.
+type Rule interface {
+	// Color returns the colour for the record, or false if the rule doesn't apply to it.
+	Color(rec *shapefile.Record) (string, bool)
+}
+
+// Categorized colours records by the value of a field, such as a country's continent.
+type Categorized struct {
+	Field string
+
+	// Colors maps field values, as they would be passed to the field's Equal method, to colours.
+	Colors map[string]string
+}
+
+// Color returns the colour of the first category that is equal to the field value.
+func (c Categorized) Color(rec *shapefile.Record) (string, bool) {
+	if rec.Attributes == nil {
+		return "", false
+	}
+
+	field, ok := rec.Field(c.Field)
+	if !ok {
+		return "", false
+	}
+
+	if color, ok := c.Colors[fmt.Sprint(field.Value())]; ok {
+		return color, true
+	}
+
+	for value, color := range c.Colors {
+		if field.Equal(value) {
+			return color, true
+		}
+	}
+	return "", false
+}
+
+// Graduated colours records by the range that a numeric field falls into, such as a country's population.
+type Graduated struct {
+	Field string
+
+	// Breaks are the ascending upper bounds of each range, which are inclusive.
+	Breaks []float64
+
+	// Colors has one more colour than there are breaks, where the last colour is used for values
+	// that are greater than the last break.
+	Colors []string
+}
+
+// Color returns the colour of the range containing the field value.
+func (g Graduated) Color(rec *shapefile.Record) (string, bool) {
+	if rec.Attributes == nil || len(g.Colors) != len(g.Breaks)+1 {
+		return "", false
+	}
+
+	field, ok := rec.Field(g.Field)
+	if !ok {
+		return "", false
+	}
+
+	value, ok := field.Value().(float64)
+	if !ok {
+		return "", false
+	}
+
+	for i, b := range g.Breaks {
+		if value <= b {
+			return g.Colors[i], true
+		}
+	}
+	return g.Colors[len(g.Colors)-1], true
+}
@@ -6,7 +6,6 @@ import (
 	"sync"
 
 	"github.com/everystreet/go-shapefile/dbf"
-	"github.com/everystreet/go-shapefile/dbf/dbase5"
 	"github.com/everystreet/go-shapefile/shp"
 )
 
@@ -32,6 +31,11 @@ type Info struct {
 	NumRecords  uint32
 	ShapeType   shp.ShapeType
 	Fields      FieldDescList
+
+	// Encoding is the name of the encoding of character fields, as specified by a .cpg file,
+	// identified by the dbf language driver ID, or detected by DetectCharacterEncoding.
+	// It's empty if the encoding was set by CharacterDecoder, or if values are read without decoding.
+	Encoding string
 }
 
 // FieldDescList is a list of field descriptors.
@@ -50,6 +54,8 @@ func (l FieldDescList) Exists(name string) bool {
 // FieldDesc provides information about an attribute field.
 type FieldDesc interface {
 	Name() string
+	Length() uint8
+	Decimals() uint8
 }
 
 // NewScanner creates a new Scanner for the provided shp and dbf files.
@@ -91,15 +97,15 @@ func (s *Scanner) Info() (*Info, error) {
 			return
 		}
 
-		var fields []FieldDesc
-		switch h := dbfHeader.(type) {
-		case *dbase5.Header:
-			fields = make([]FieldDesc, len(h.Fields))
-			for i, f := range h.Fields {
-				fields[i] = f
-			}
-		default:
-			err = fmt.Errorf("unrecognized dbf header")
+		descs := dbfHeader.FieldDescs()
+		fields := make([]FieldDesc, len(descs))
+		for i, f := range descs {
+			fields[i] = f
+		}
+
+		var encoding string
+		if encoding, err = s.characterEncoding(dbfHeader); err != nil {
+			err = fmt.Errorf("failed to detect character encoding: %w", err)
 			return
 		}
 
@@ -108,12 +114,44 @@ func (s *Scanner) Info() (*Info, error) {
 			NumRecords:  dbfHeader.NumRecords(),
 			ShapeType:   shpHeader.ShapeType,
 			Fields:      fields,
+			Encoding:    encoding,
 		}
 	})
 
 	return &s.info, err
 }
 
+// characterEncoding chooses the encoding of character fields if one wasn't set by an option,
+// and returns its name.
+func (s *Scanner) characterEncoding(h dbf.Header) (string, error) {
+	if s.opts.hasEncoding {
+		return s.opts.encoding, nil
+	}
+
+	enc, ok := dbf.LanguageDriverEncoding(h.LanguageDriverID())
+	if s.opts.detect {
+		charset, err := s.dbf.DetectCharset(s.opts.dbfOptions()...)
+		if err != nil {
+			return "", err
+		}
+
+		switch charset {
+		case dbf.CharsetUTF8:
+			enc, ok = dbf.UTF8, true
+		case dbf.CharsetLegacy:
+			if !ok {
+				enc, ok = dbf.Windows1252, true
+			}
+		}
+	}
+
+	if !ok {
+		return "", nil
+	}
+	s.opts.dbf = append(s.opts.dbf, dbf.CharacterDecoder(enc.NewDecoder()))
+	return enc.Name, nil
+}
+
 // Scan begins reading the shp and dbf files for records. Records can be accessed from the Record method.
 // An error is returned if there's a problem parsing the header of either file.
 // Errors that are encountered when parsing records must be checked with the Err method.
@@ -123,10 +161,18 @@ func (s *Scanner) Scan() error {
 		return err
 	}
 
+	if s.opts.where != nil {
+		for _, name := range s.opts.where.Fields() {
+			if !info.Fields.Exists(name) {
+				return fmt.Errorf("field '%s' in expression does not exist", name)
+			}
+		}
+	}
+
 	s.scanOnce.Do(func() {
 		if err = s.shp.Scan(); err != nil {
 			return
-		} else if err = s.dbf.Scan(s.opts.dbf...); err != nil {
+		} else if err = s.dbf.Scan(s.opts.dbfOptions()...); err != nil {
 			return
 		}
 
@@ -160,9 +206,22 @@ func (s *Scanner) Scan() error {
 					return
 				}
 
+				var ok bool
+				if shape, ok = s.opts.filter(shape); !ok {
+					continue
+				}
+
+				attrs, ok, err := s.opts.match(attr)
+				if err != nil {
+					s.setErr(fmt.Errorf("failed to evaluate expression for record %d: %w", i+1, err))
+					return
+				} else if !ok {
+					continue
+				}
+
 				s.recordsCh <- &Record{
 					Shape:      shape,
-					Attributes: attr,
+					Attributes: attrs,
 				}
 			}
 		}()
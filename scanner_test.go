@@ -1,12 +1,19 @@
 package shapefile_test
 
 import (
+	"io"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/everystreet/go-shapefile"
+	"github.com/everystreet/go-shapefile/dbf"
+	"github.com/everystreet/go-shapefile/dbf/dbase5"
+	"github.com/everystreet/go-shapefile/dbf/expr"
+	"github.com/everystreet/go-shapefile/shp"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
 )
 
 func TestScanner(t *testing.T) {
@@ -39,3 +46,205 @@ func TestScanner(t *testing.T) {
 	require.NoError(t, shp.Close())
 	require.NoError(t, dbf.Close())
 }
+
+func TestScannerClipBox(t *testing.T) {
+	shpFile, err := os.Open(filepath.Join("testdata", "ne_110m_admin_0_sovereignty.shp"))
+	require.NoError(t, err)
+
+	dbfFile, err := os.Open(filepath.Join("testdata", "ne_110m_admin_0_sovereignty.dbf"))
+	require.NoError(t, err)
+
+	box := shp.BoundingBox{MinX: -10, MinY: 35, MaxX: 30, MaxY: 60}
+	s := shapefile.NewScanner(shpFile, dbfFile, shapefile.ClipBox(box))
+
+	err = s.Scan()
+	require.NoError(t, err)
+
+	var names []string
+	for {
+		rec := s.Record()
+		if rec == nil {
+			break
+		}
+
+		require.Equal(t, shp.Shapes{rec.Shape}.BoundingBox(), rec.Shape.(shp.Polygon).BoundingBox)
+		require.True(t, rec.Shape.(shp.Polygon).BoundingBox.MinX >= box.MinX)
+		require.True(t, rec.Shape.(shp.Polygon).BoundingBox.MaxY <= box.MaxY)
+
+		f, ok := rec.Field("ADMIN")
+		require.True(t, ok)
+		names = append(names, f.Value().(string))
+	}
+
+	require.NoError(t, s.Err())
+	require.Contains(t, names, "Germany")
+	require.Contains(t, names, "France")
+	require.NotContains(t, names, "Brazil")
+	require.Less(t, len(names), 171)
+
+	require.NoError(t, shpFile.Close())
+	require.NoError(t, dbfFile.Close())
+}
+
+func TestScannerSimplification(t *testing.T) {
+	f, err := shapefile.OpenPath(filepath.Join("testdata", "ne_110m_admin_0_sovereignty.shp"),
+		shapefile.FilterFields("ADMIN"), shapefile.Simplification(shp.Simplifier{Tolerance: 5}))
+	require.NoError(t, err)
+	defer f.Close()
+
+	require.NoError(t, f.Scan())
+
+	var names []string
+	for {
+		rec := f.Record()
+		if rec == nil {
+			break
+		}
+
+		f, ok := rec.Field("ADMIN")
+		require.True(t, ok)
+		names = append(names, f.Value().(string))
+
+		// Shapes stay with their own attributes when others are skipped
+		if names[len(names)-1] == "Brazil" {
+			require.True(t, rec.Shape.(shp.Polygon).BoundingBox.Contains(shp.MakePoint(-50, -10)))
+		}
+	}
+
+	require.NoError(t, f.Err())
+	require.Contains(t, names, "Brazil")
+	require.NotContains(t, names, "Belgium")
+	require.Less(t, len(names), 171)
+}
+
+func TestScannerWhere(t *testing.T) {
+	e, err := expr.Parse("POP_EST > 100000000 AND CONTINENT = 'Asia'")
+	require.NoError(t, err)
+
+	f, err := shapefile.OpenPath(filepath.Join("testdata", "ne_110m_admin_0_sovereignty.shp"),
+		shapefile.FilterFields("NAME"), shapefile.Where(e))
+	require.NoError(t, err)
+	defer f.Close()
+
+	require.NoError(t, f.Scan())
+
+	var names []string
+	for {
+		rec := f.Record()
+		if rec == nil {
+			break
+		}
+
+		// Fields used only by the expression are hidden
+		require.Len(t, rec.Fields(), 1)
+		_, ok := rec.Field("POP_EST")
+		require.False(t, ok)
+
+		name, ok := rec.Field("NAME")
+		require.True(t, ok)
+		names = append(names, name.Value().(string))
+	}
+
+	require.NoError(t, f.Err())
+	require.Contains(t, names, "China")
+	require.Contains(t, names, "India")
+	require.NotContains(t, names, "Brazil")
+	require.NotContains(t, names, "Nepal")
+}
+
+func TestScannerWhereMissingField(t *testing.T) {
+	e, err := expr.Parse("MISSING = 1")
+	require.NoError(t, err)
+
+	f, err := shapefile.OpenPath(filepath.Join("testdata", "ne_110m_admin_0_sovereignty.shp"), shapefile.Where(e))
+	require.NoError(t, err)
+	defer f.Close()
+
+	require.Error(t, f.Scan())
+}
+
+func TestScannerEncoding(t *testing.T) {
+	cp866, err := charmap.CodePage866.NewEncoder().String("Привет")
+	require.NoError(t, err)
+	windows1252, err := charmap.Windows1252.NewEncoder().String("Zürich")
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		name     string
+		ldid     byte
+		value    string
+		opts     []shapefile.Option
+		encoding string
+		want     string
+	}{
+		{"language driver", 0x26, cp866, nil, "ibm866", "Привет"},
+		{"ansi language driver", 0x57, "Zürich", nil, "", "Zürich"},
+		{"detect utf-8", 0x57, "Zürich", []shapefile.Option{shapefile.DetectCharacterEncoding()}, "utf-8", "Zürich"},
+		{"detect ansi", 0x57, windows1252, []shapefile.Option{shapefile.DetectCharacterEncoding()}, "windows-1252", "Zürich"},
+		{"detect legacy", 0, windows1252, []shapefile.Option{shapefile.DetectCharacterEncoding()}, "windows-1252", "Zürich"},
+		{"detect ascii", 0, "Zurich", []shapefile.Option{shapefile.DetectCharacterEncoding()}, "", "Zurich"},
+		{"character decoder", 0x26, "Zürich", []shapefile.Option{shapefile.CharacterDecoder(encoding.Nop.NewDecoder())}, "", "Zürich"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			shpR, dbfR := writeLayer(t, tt.ldid, tt.value)
+
+			s := shapefile.NewScanner(shpR, dbfR, tt.opts...)
+			info, err := s.Info()
+			require.NoError(t, err)
+			require.Equal(t, tt.encoding, info.Encoding)
+
+			require.NoError(t, s.Scan())
+			rec := s.Record()
+			require.NotNil(t, rec)
+			require.Nil(t, s.Record())
+			require.NoError(t, s.Err())
+
+			f, ok := rec.Attributes.Field("NAME")
+			require.True(t, ok)
+			require.Equal(t, tt.want, f.Value())
+		})
+	}
+}
+
+// writeLayer writes a layer with a single point, and a NAME field containing the raw bytes of value.
+func writeLayer(t *testing.T, ldid byte, value string) (io.Reader, io.Reader) {
+	dir := t.TempDir()
+
+	shpF, err := os.Create(filepath.Join(dir, "layer.shp"))
+	require.NoError(t, err)
+	shxF, err := os.Create(filepath.Join(dir, "layer.shx"))
+	require.NoError(t, err)
+
+	w, err := shp.NewWriter(shpF, shxF, shp.PointType)
+	require.NoError(t, err)
+	require.NoError(t, w.Write(shp.MakePoint(1, 2)))
+	require.NoError(t, w.Close())
+	require.NoError(t, shpF.Close())
+	require.NoError(t, shxF.Close())
+
+	name, err := dbase5.NewFieldDesc("NAME", dbase5.CharacterType, 20, 0)
+	require.NoError(t, err)
+
+	dbfF, err := os.Create(filepath.Join(dir, "layer.dbf"))
+	require.NoError(t, err)
+
+	d, err := dbf.NewWriter(dbfF, []*dbase5.FieldDesc{name}, encoding.Nop.NewEncoder())
+	require.NoError(t, err)
+	require.NoError(t, d.Write(value))
+	require.NoError(t, d.Close())
+
+	_, err = dbfF.WriteAt([]byte{ldid}, 29)
+	require.NoError(t, err)
+	require.NoError(t, dbfF.Close())
+
+	shpR, err := os.Open(filepath.Join(dir, "layer.shp"))
+	require.NoError(t, err)
+	dbfR, err := os.Open(filepath.Join(dir, "layer.dbf"))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		shpR.Close()
+		dbfR.Close()
+	})
+	return shpR, dbfR
+}
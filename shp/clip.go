@@ -0,0 +1,267 @@
+package shp
+
+import (
+	"math"
+
+	"github.com/golang/geo/r2"
+)
+
+// Intersects returns true if the two boxes overlap, including if they only touch.
+func (b BoundingBox) Intersects(o BoundingBox) bool {
+	return b.MinX <= o.MaxX && o.MinX <= b.MaxX && b.MinY <= o.MaxY && o.MinY <= b.MaxY
+}
+
+// Contains returns true if the point is inside the box, or on its boundary.
+func (b BoundingBox) Contains(p Point) bool {
+	return p.X >= b.MinX && p.X <= b.MaxX && p.Y >= b.MinY && p.Y <= b.MaxY
+}
+
+func (b BoundingBox) containsBox(o BoundingBox) bool {
+	return o.MinX >= b.MinX && o.MaxX <= b.MaxX && o.MinY >= b.MinY && o.MaxY <= b.MaxY
+}
+
+// BoundingBoxOf returns the bounding box of any shape.
+// A Point's bounding box has zero width and height.
+func BoundingBoxOf(shape Shape) BoundingBox {
+	switch s := shape.(type) {
+	case Point:
+		return BoundingBox{MinX: s.X, MinY: s.Y, MaxX: s.X, MaxY: s.Y}
+	case Polyline:
+		return s.BoundingBox
+	case Polygon:
+		return s.BoundingBox
+	default:
+		return Shapes{shape}.BoundingBox()
+	}
+}
+
+// Clip returns the part of the shape that is inside the box.
+// false is returned if no part of the shape is inside the box.
+func Clip(shape Shape, box BoundingBox) (Shape, bool) {
+	switch s := shape.(type) {
+	case Point:
+		return s.Clip(box)
+	case Polyline:
+		return s.Clip(box)
+	case Polygon:
+		return s.Clip(box)
+	default:
+		return shape, BoundingBoxOf(shape).Intersects(box)
+	}
+}
+
+// Clip returns the point if it is inside the box, and false otherwise.
+func (p Point) Clip(box BoundingBox) (Point, bool) {
+	return p, box.Contains(p)
+}
+
+// Clip returns the part of the polyline that is inside the box.
+// Parts that leave and re-enter the box are split into multiple parts.
+// false is returned if no part of the polyline is inside the box.
+func (p Polyline) Clip(box BoundingBox) (Polyline, bool) {
+	if !p.BoundingBox.Intersects(box) {
+		return Polyline{}, false
+	} else if box.containsBox(p.BoundingBox) {
+		return p, true
+	}
+
+	var parts []Part
+	for _, part := range p.Parts {
+		var current Part
+		for i := 1; i < len(part); i++ {
+			a, b, ok := clipSegment(part[i-1], part[i], box)
+			if !ok {
+				continue
+			}
+
+			if len(current) > 0 && current[len(current)-1].Point != a.Point {
+				parts = append(parts, current)
+				current = nil
+			}
+
+			if len(current) == 0 {
+				current = append(current, a)
+			}
+			current = append(current, b)
+
+			// The segment left the box, so the next one must start a new part
+			if b.Point != part[i].Point {
+				parts = append(parts, current)
+				current = nil
+			}
+		}
+
+		if len(current) > 1 {
+			parts = append(parts, current)
+		}
+	}
+
+	if len(parts) == 0 {
+		return Polyline{}, false
+	}
+
+	return Polyline{
+		BoundingBox: boxedParts(parts),
+		Parts:       parts,
+		number:      p.number,
+	}, true
+}
+
+// Clip returns the part of the polygon that is inside the box, using the Sutherland–Hodgman algorithm.
+// Each ring, including holes, is clipped separately and retains its orientation.
+// Rings that are entirely outside the box, or that are left with no area, are removed.
+// false is returned if no outer ring remains.
+func (p Polygon) Clip(box BoundingBox) (Polygon, bool) {
+	if !p.BoundingBox.Intersects(box) {
+		return Polygon{}, false
+	} else if box.containsBox(p.BoundingBox) {
+		return p, true
+	}
+
+	var parts []Part
+	var outer bool
+	for _, part := range p.Parts {
+		ring := clipRing(part, box)
+		if ring.degenerate() {
+			continue
+		}
+
+		parts = append(parts, ring)
+		if part.IsClockwise() {
+			outer = true
+		}
+	}
+
+	if !outer {
+		return Polygon{}, false
+	}
+
+	return Polygon{
+		BoundingBox: boxedParts(parts),
+		Parts:       parts,
+		number:      p.number,
+	}, true
+}
+
+// clipSegment clips the segment between a and b to the box, using the Liang–Barsky algorithm.
+func clipSegment(a, b Point, box BoundingBox) (Point, Point, bool) {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	t0, t1 := 0.0, 1.0
+
+	for _, edge := range [][2]float64{
+		{-dx, a.X - box.MinX},
+		{dx, box.MaxX - a.X},
+		{-dy, a.Y - box.MinY},
+		{dy, box.MaxY - a.Y},
+	} {
+		p, q := edge[0], edge[1]
+		if p == 0 {
+			if q < 0 {
+				return Point{}, Point{}, false
+			}
+			continue
+		}
+
+		r := q / p
+		if p < 0 {
+			t0 = math.Max(t0, r)
+		} else {
+			t1 = math.Min(t1, r)
+		}
+
+		if t0 > t1 {
+			return Point{}, Point{}, false
+		}
+	}
+
+	start, end := MakePoint(a.X, a.Y), MakePoint(b.X, b.Y)
+	if t0 > 0 {
+		start = MakePoint(a.X+t0*dx, a.Y+t0*dy)
+	}
+	if t1 < 1 {
+		end = MakePoint(a.X+t1*dx, a.Y+t1*dy)
+	}
+	return start, end, true
+}
+
+// clipRing clips a closed ring to the box, returning a closed ring.
+func clipRing(part Part, box BoundingBox) Part {
+	edges := []struct {
+		inside    func(Point) bool
+		intersect func(a, b Point) Point
+	}{
+		{
+			func(p Point) bool { return p.X >= box.MinX },
+			func(a, b Point) Point { return intersectX(a, b, box.MinX) },
+		},
+		{
+			func(p Point) bool { return p.X <= box.MaxX },
+			func(a, b Point) Point { return intersectX(a, b, box.MaxX) },
+		},
+		{
+			func(p Point) bool { return p.Y >= box.MinY },
+			func(a, b Point) Point { return intersectY(a, b, box.MinY) },
+		},
+		{
+			func(p Point) bool { return p.Y <= box.MaxY },
+			func(a, b Point) Point { return intersectY(a, b, box.MaxY) },
+		},
+	}
+
+	// Work with an open ring
+	points := copyPart(part)
+	if n := len(points); n > 1 && points[0].Point == points[n-1].Point {
+		points = points[:n-1]
+	}
+
+	for _, edge := range edges {
+		if len(points) == 0 {
+			break
+		}
+
+		in := points
+		points = make(Part, 0, len(in)+4)
+		prev := in[len(in)-1]
+		for _, p := range in {
+			switch {
+			case edge.inside(p) && edge.inside(prev):
+				points = append(points, p)
+			case edge.inside(p):
+				points = append(points, edge.intersect(prev, p), p)
+			case edge.inside(prev):
+				points = append(points, edge.intersect(prev, p))
+			}
+			prev = p
+		}
+	}
+
+	out := make(Part, 0, len(points)+1)
+	for _, p := range points {
+		if len(out) == 0 || out[len(out)-1].Point != p.Point {
+			out = append(out, p)
+		}
+	}
+
+	if len(out) > 0 && out[0].Point != out[len(out)-1].Point {
+		out = append(out, out[0])
+	}
+	return out
+}
+
+// degenerate returns true if the ring has fewer than 3 distinct points or no area,
+// such as when clipping leaves a sliver along an edge of the box.
+func (p Part) degenerate() bool {
+	distinct := make(map[r2.Point]struct{}, len(p))
+	for _, pt := range p {
+		distinct[pt.Point] = struct{}{}
+	}
+	return len(distinct) < 3 || p.signedArea() == 0
+}
+
+func intersectX(a, b Point, x float64) Point {
+	return MakePoint(x, a.Y+(b.Y-a.Y)*(x-a.X)/(b.X-a.X))
+}
+
+func intersectY(a, b Point, y float64) Point {
+	return MakePoint(a.X+(b.X-a.X)*(y-a.Y)/(b.Y-a.Y), y)
+}
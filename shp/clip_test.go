@@ -0,0 +1,69 @@
+package shp_test
+
+import (
+	"testing"
+
+	"github.com/everystreet/go-shapefile/shp"
+	"github.com/stretchr/testify/require"
+)
+
+var clipBox = shp.BoundingBox{MinX: 0, MinY: 0, MaxX: 10, MaxY: 10}
+
+func TestClipPolyline(t *testing.T) {
+	p := shp.Polyline{
+		BoundingBox: shp.BoundingBox{MinX: -5, MinY: 2, MaxX: 15, MaxY: 12},
+		Parts: []shp.Part{
+			// Enters, leaves through the top, and re-enters
+			ring(-5, 5, 5, 5, 5, 12, 8, 12, 8, 2, 15, 2),
+		},
+	}
+
+	clipped, ok := p.Clip(clipBox)
+	require.True(t, ok)
+	require.Len(t, clipped.Parts, 2)
+	pointsEqual(t, ring(0, 5, 5, 5, 5, 10), clipped.Parts[0])
+	pointsEqual(t, ring(8, 10, 8, 2, 10, 2), clipped.Parts[1])
+	require.Equal(t, shp.BoundingBox{MinX: 0, MinY: 2, MaxX: 10, MaxY: 10}, clipped.BoundingBox)
+
+	_, ok = p.Clip(shp.BoundingBox{MinX: 20, MinY: 20, MaxX: 30, MaxY: 30})
+	require.False(t, ok)
+}
+
+func TestClipPolygon(t *testing.T) {
+	p := shp.Polygon{
+		BoundingBox: shp.BoundingBox{MinX: -5, MinY: -5, MaxX: 5, MaxY: 5},
+		Parts: []shp.Part{
+			ring(-5, -5, -5, 5, 5, 5, 5, -5, -5, -5),
+			ring(1, 1, 2, 1, 2, 2, 1, 2, 1, 1),
+			ring(-3, -3, -2, -3, -2, -2, -3, -2, -3, -3),
+		},
+	}
+
+	clipped, ok := p.Clip(clipBox)
+	require.True(t, ok)
+	require.Len(t, clipped.Parts, 2)
+	pointsEqual(t, ring(0, 0, 0, 5, 5, 5, 5, 0, 0, 0), clipped.Parts[0])
+	pointsEqual(t, p.Parts[1], clipped.Parts[1])
+	require.True(t, clipped.Parts[0].IsClockwise())
+	require.Equal(t, 24.0, clipped.Area())
+	require.Equal(t, shp.BoundingBox{MinX: 0, MinY: 0, MaxX: 5, MaxY: 5}, clipped.BoundingBox)
+
+	// Only touches the edge of the box at 3 points, which leaves no area
+	p = shp.Polygon{
+		BoundingBox: shp.BoundingBox{MinX: -8, MinY: 0, MaxX: 0, MaxY: 10},
+		Parts: []shp.Part{
+			ring(-5, 0, -8, 5, -5, 10, 0, 8, -5, 6, 0, 5, -5, 3, 0, 2, -5, 0),
+		},
+	}
+
+	_, ok = p.Clip(clipBox)
+	require.False(t, ok)
+}
+
+func TestClipPoint(t *testing.T) {
+	_, ok := shp.MakePoint(5, 5).Clip(clipBox)
+	require.True(t, ok)
+
+	_, ok = shp.MakePoint(11, 5).Clip(clipBox)
+	require.False(t, ok)
+}
@@ -51,6 +51,9 @@ func DecodeHeader(buf []byte, opts ...Option) (Header, error) {
 		}
 	}
 
+	if conf.transform != nil {
+		out.BoundingBox = transformBox(out.BoundingBox, conf.transform)
+	}
 	return out, nil
 }
 
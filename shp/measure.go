@@ -0,0 +1,333 @@
+package shp
+
+import (
+	"math"
+	"sort"
+
+	"github.com/golang/geo/r2"
+	"github.com/golang/geo/s2"
+)
+
+// earthRadius is the mean radius of the Earth in metres, used for geodesic measurements.
+const earthRadius = 6371008.8
+
+// IsClockwise returns true if the points of the part are ordered clockwise.
+// In a polygon, clockwise parts are outer rings and counterclockwise parts are holes.
+func (p Part) IsClockwise() bool {
+	return p.signedArea() < 0
+}
+
+// signedArea returns the planar area enclosed by the part, which is negative if the part is clockwise.
+func (p Part) signedArea() float64 {
+	var sum float64
+	for i := 0; i < len(p); i++ {
+		a, b := p[i], p[(i+1)%len(p)]
+		sum += a.X*b.Y - b.X*a.Y
+	}
+	return sum / 2
+}
+
+// containsPoint returns true if the point is inside the ring, using the even-odd rule.
+func (p Part) containsPoint(pt r2.Point) bool {
+	var inside bool
+	for i, j := 0, len(p)-1; i < len(p); j, i = i, i+1 {
+		a, b := p[i], p[j]
+		if (a.Y > pt.Y) != (b.Y > pt.Y) && pt.X < (b.X-a.X)*(pt.Y-a.Y)/(b.Y-a.Y)+a.X {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// Distance returns the planar distance between two points.
+func (p Point) Distance(q Point) float64 {
+	return p.Sub(q.Point).Norm()
+}
+
+// GeodesicDistance returns the distance in metres between two points, where X is longitude and Y is latitude.
+func (p Point) GeodesicDistance(q Point) float64 {
+	return pointToLatLng(p).Distance(pointToLatLng(q)).Radians() * earthRadius
+}
+
+// Centroid returns the point itself.
+func (p Point) Centroid() Point {
+	return MakePoint(p.X, p.Y)
+}
+
+// InteriorPoint returns the point itself.
+func (p Point) InteriorPoint() Point {
+	return MakePoint(p.X, p.Y)
+}
+
+// Length returns the planar length of all parts.
+func (p Polyline) Length() float64 {
+	var length float64
+	for _, part := range p.Parts {
+		for i := 1; i < len(part); i++ {
+			length += part[i].Distance(part[i-1])
+		}
+	}
+	return length
+}
+
+// GeodesicLength returns the length in metres of all parts, where X is longitude and Y is latitude.
+func (p Polyline) GeodesicLength() float64 {
+	var length float64
+	for _, part := range p.Parts {
+		latlngs := make([]s2.LatLng, len(part))
+		for i, point := range part {
+			latlngs[i] = pointToLatLng(point)
+		}
+		length += s2.PolylineFromLatLngs(latlngs).Length().Radians()
+	}
+	return length * earthRadius
+}
+
+// Centroid returns the centre of mass of the polyline, where each segment is weighted by its length.
+// If the polyline has no length, the mean of its points is returned.
+func (p Polyline) Centroid() Point {
+	var sum r2.Point
+	var length float64
+	for _, part := range p.Parts {
+		for i := 1; i < len(part); i++ {
+			l := part[i].Distance(part[i-1])
+			sum = sum.Add(part[i].Add(part[i-1].Point).Mul(l / 2))
+			length += l
+		}
+	}
+
+	if length == 0 {
+		return meanPoint(p.points())
+	}
+	return MakePoint(sum.X/length, sum.Y/length)
+}
+
+// InteriorPoint returns the vertex of the polyline that is closest to its centroid.
+func (p Polyline) InteriorPoint() Point {
+	centroid := p.Centroid()
+
+	var out Point
+	min := math.Inf(1)
+	for _, part := range p.Parts {
+		for _, point := range part {
+			if d := point.Distance(centroid); d < min {
+				min = d
+				out = MakePoint(point.X, point.Y)
+			}
+		}
+	}
+	return out
+}
+
+// Area returns the planar area of the polygon, excluding holes.
+func (p Polygon) Area() float64 {
+	var sum float64
+	for _, part := range p.Parts {
+		sum += part.signedArea()
+	}
+	return math.Abs(sum)
+}
+
+// GeodesicArea returns the area in square metres of the polygon, excluding holes,
+// where X is longitude and Y is latitude.
+func (p Polygon) GeodesicArea() float64 {
+	var sum float64
+	for _, part := range p.Parts {
+		points := ringToS2Points(part, part.IsClockwise())
+		if len(points) < 3 {
+			continue
+		}
+
+		area := s2.LoopFromPoints(points).Area()
+		if part.IsClockwise() {
+			sum += area
+		} else {
+			sum -= area
+		}
+	}
+	return math.Abs(sum) * earthRadius * earthRadius
+}
+
+// Perimeter returns the planar length of all rings, including holes.
+func (p Polygon) Perimeter() float64 {
+	return Polyline(p).Length()
+}
+
+// GeodesicPerimeter returns the length in metres of all rings, including holes,
+// where X is longitude and Y is latitude.
+func (p Polygon) GeodesicPerimeter() float64 {
+	return Polyline(p).GeodesicLength()
+}
+
+// Centroid returns the centre of mass of the polygon, taking holes into account.
+// If the polygon has no area, the centroid of its rings is returned.
+func (p Polygon) Centroid() Point {
+	var x, y, area float64
+	for _, part := range p.Parts {
+		for i := 0; i < len(part); i++ {
+			a, b := part[i], part[(i+1)%len(part)]
+			cross := a.X*b.Y - b.X*a.Y
+			x += (a.X + b.X) * cross
+			y += (a.Y + b.Y) * cross
+			area += cross
+		}
+	}
+
+	if area == 0 {
+		return Polyline(p).Centroid()
+	}
+	return MakePoint(x/(3*area), y/(3*area))
+}
+
+// InteriorPoint returns a point that is guaranteed to be inside the polygon, and not inside any of its holes,
+// unlike the centroid which may be outside of concave polygons.
+// The point is the middle of the widest span of a horizontal line through the largest outer ring.
+func (p Polygon) InteriorPoint() Point {
+	var largest *Polygon
+	var max float64
+	polygons := p.Polygons()
+	for i := range polygons {
+		if area := polygons[i].Area(); largest == nil || area > max {
+			largest, max = &polygons[i], area
+		}
+	}
+
+	if largest == nil || max == 0 {
+		return p.Centroid()
+	}
+
+	y := (largest.BoundingBox.MinY + largest.BoundingBox.MaxY) / 2
+
+	var xs []float64
+	for _, part := range largest.Parts {
+		for i, j := 0, len(part)-1; i < len(part); j, i = i, i+1 {
+			a, b := part[i], part[j]
+			if (a.Y > y) != (b.Y > y) {
+				xs = append(xs, (b.X-a.X)*(y-a.Y)/(b.Y-a.Y)+a.X)
+			}
+		}
+	}
+	sort.Float64s(xs)
+
+	var x, width float64
+	for i := 0; i+1 < len(xs); i += 2 {
+		if w := xs[i+1] - xs[i]; w > width {
+			x, width = (xs[i]+xs[i+1])/2, w
+		}
+	}
+
+	if width == 0 {
+		return p.Centroid()
+	}
+	return MakePoint(x, y)
+}
+
+// Contains returns true if the point is inside the polygon and not inside any of its holes.
+func (p Polygon) Contains(pt Point) bool {
+	var inside bool
+	for _, part := range p.Parts {
+		if part.containsPoint(pt.Point) {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// Polygons splits the polygon into one polygon for each outer ring, where the first part of each is the outer ring,
+// followed by the holes it contains. Each hole is assigned to the smallest outer ring that contains it,
+// and holes that aren't contained by any outer ring are treated as outer rings.
+func (p Polygon) Polygons() []Polygon {
+	shell := shells(p.Parts)
+
+	// Outer rings come first, followed by holes that aren't contained by any outer ring
+	var groups [][]Part
+	index := make(map[int]int, len(p.Parts))
+	for _, outers := range []bool{true, false} {
+		for i, part := range p.Parts {
+			if shell[i] == i && part.IsClockwise() == outers {
+				index[i] = len(groups)
+				groups = append(groups, []Part{part})
+			}
+		}
+	}
+
+	for i, part := range p.Parts {
+		if shell[i] != i {
+			groups[index[shell[i]]] = append(groups[index[shell[i]]], part)
+		}
+	}
+
+	out := make([]Polygon, len(groups))
+	for i, parts := range groups {
+		out[i] = Polygon{
+			BoundingBox: boxFromPoints(Polyline{Parts: parts}.points()),
+			Parts:       parts,
+			number:      p.number,
+		}
+	}
+	return out
+}
+
+// shells returns the index of the outer ring of each part, where each hole belongs to the smallest outer ring
+// that contains it. Outer rings, and holes that aren't contained by any outer ring, are their own shells.
+func shells(parts []Part) []int {
+	clockwise := make([]bool, len(parts))
+	for i, part := range parts {
+		clockwise[i] = part.IsClockwise()
+	}
+
+	out := make([]int, len(parts))
+	for i, hole := range parts {
+		out[i] = i
+		if clockwise[i] || len(hole) == 0 {
+			continue
+		}
+
+		for j, outer := range parts {
+			if !clockwise[j] || !outer.containsPoint(hole[0].Point) {
+				continue
+			} else if out[i] == i || math.Abs(outer.signedArea()) < math.Abs(parts[out[i]].signedArea()) {
+				out[i] = j
+			}
+		}
+	}
+	return out
+}
+
+func meanPoint(points []r2.Point) Point {
+	if len(points) == 0 {
+		return Point{}
+	}
+
+	var sum r2.Point
+	for _, p := range points {
+		sum = sum.Add(p)
+	}
+	return MakePoint(sum.X/float64(len(points)), sum.Y/float64(len(points)))
+}
+
+// ringToS2Points converts a ring to a list of points suitable for an s2.Loop,
+// omitting repeated and closing points and optionally reversing the order.
+func ringToS2Points(part Part, reverse bool) []s2.Point {
+	distinct := make([]Point, 0, len(part))
+	for _, p := range part {
+		if len(distinct) == 0 || distinct[len(distinct)-1].Point != p.Point {
+			distinct = append(distinct, p)
+		}
+	}
+
+	n := len(distinct)
+	for n > 1 && distinct[0].Point == distinct[n-1].Point {
+		n--
+	}
+
+	points := make([]s2.Point, n)
+	for i := 0; i < n; i++ {
+		j := i
+		if reverse {
+			j = n - 1 - i
+		}
+		points[i] = s2.PointFromLatLng(pointToLatLng(distinct[j]))
+	}
+	return points
+}
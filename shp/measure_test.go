@@ -0,0 +1,80 @@
+package shp_test
+
+import (
+	"testing"
+
+	"github.com/everystreet/go-shapefile/shp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolygonMeasurements(t *testing.T) {
+	// 10x10 square with a 2x2 hole, and a separate 1x1 square.
+	p := shp.Polygon{
+		BoundingBox: shp.BoundingBox{MinX: 0, MinY: 0, MaxX: 21, MaxY: 10},
+		Parts: []shp.Part{
+			ring(0, 0, 0, 10, 10, 10, 10, 0, 0, 0),
+			ring(20, 0, 20, 1, 21, 1, 21, 0, 20, 0),
+			ring(4, 4, 6, 4, 6, 6, 4, 6, 4, 4),
+		},
+	}
+
+	require.Equal(t, 97.0, p.Area())
+	require.Equal(t, 40.0+4+8, p.Perimeter())
+
+	centroid := p.Centroid()
+	require.InDelta(t, (5*96+20.5)/97, centroid.X, 1e-9)
+	require.InDelta(t, (5*96+0.5)/97, centroid.Y, 1e-9)
+
+	require.True(t, p.Contains(shp.MakePoint(1, 1)))
+	require.True(t, p.Contains(shp.MakePoint(20.5, 0.5)))
+	require.False(t, p.Contains(shp.MakePoint(5, 5)))
+	require.False(t, p.Contains(shp.MakePoint(15, 5)))
+
+	interior := p.InteriorPoint()
+	require.True(t, p.Contains(interior))
+
+	polygons := p.Polygons()
+	require.Len(t, polygons, 2)
+	require.Len(t, polygons[0].Parts, 2)
+	require.Len(t, polygons[1].Parts, 1)
+	require.Equal(t, shp.BoundingBox{MinX: 20, MinY: 0, MaxX: 21, MaxY: 1}, polygons[1].BoundingBox)
+}
+
+func TestGeodesicMeasurements(t *testing.T) {
+	// 1x1 degree square on the equator.
+	p := shp.Polygon{
+		Parts: []shp.Part{
+			ring(0, 0, 0, 1, 1, 1, 1, 0, 0, 0),
+		},
+	}
+
+	require.InEpsilon(t, 1.2364e10, p.GeodesicArea(), 1e-3)
+	require.InEpsilon(t, 4*111195.0, p.GeodesicPerimeter(), 1e-3)
+
+	a, b := shp.MakePoint(0, 0), shp.MakePoint(0, 1)
+	require.InEpsilon(t, 111195.0, a.GeodesicDistance(b), 1e-4)
+	require.Equal(t, 1.0, a.Distance(b))
+}
+
+func TestPolylineMeasurements(t *testing.T) {
+	p := shp.Polyline{
+		Parts: []shp.Part{
+			ring(0, 0, 4, 0, 4, 2),
+		},
+	}
+
+	require.Equal(t, 6.0, p.Length())
+
+	centroid := p.Centroid()
+	require.InDelta(t, (4*2+2*4)/6.0, centroid.X, 1e-9)
+	require.InDelta(t, (2*1)/6.0, centroid.Y, 1e-9)
+	require.Equal(t, shp.MakePoint(4, 0), p.InteriorPoint())
+}
+
+func ring(coords ...float64) shp.Part {
+	part := make(shp.Part, len(coords)/2)
+	for i := range part {
+		part[i] = shp.MakePoint(coords[i*2], coords[i*2+1])
+	}
+	return part
+}
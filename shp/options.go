@@ -10,7 +10,30 @@ func PointPrecision(p uint) Option {
 	}
 }
 
+// TransformFunc transforms a single coordinate.
+type TransformFunc func(x, y float64) (float64, float64)
+
+// Transform sets a function that is applied to the X and Y values of every decoded point,
+// after PointPrecision but before the shapes are returned.
+// The bounding box of each shape is recalculated from its transformed points, and the file header box
+// from points sampled along its edges.
+func Transform(fn TransformFunc) Option {
+	return func(c *config) {
+		c.transform = fn
+	}
+}
+
+// Simplification sets a Simplifier that is applied to every decoded shape, after any Transform.
+// Shapes that collapse entirely are skipped, in the same way as null shapes.
+func Simplification(s Simplifier) Option {
+	return func(c *config) {
+		c.simplifier = &s
+	}
+}
+
 // Config for shp parsing.
 type config struct {
-	precision *uint
+	precision  *uint
+	transform  TransformFunc
+	simplifier *Simplifier
 }
@@ -29,6 +29,14 @@ func DecodePolylineP(buf []byte, num uint32, precision uint) (Polyline, error) {
 	return decodePolyline(buf, num, &precision)
 }
 
+// MakePolyline creates a polyline from its parts, calculating the bounding box.
+func MakePolyline(parts ...Part) Polyline {
+	return Polyline{
+		BoundingBox: boxedParts(parts),
+		Parts:       parts,
+	}
+}
+
 // Type is PolylineType.
 func (p Polyline) Type() ShapeType {
 	return PolylineType
@@ -71,6 +79,40 @@ func DecodePolygonP(buf []byte, num uint32, precision uint) (Polygon, error) {
 	return Polygon(p), nil
 }
 
+// MakePolygon creates a polygon from a list of polygons, each consisting of an outer ring followed by its holes,
+// calculating the bounding box. Rings are reversed where necessary so that outer rings are clockwise
+// and holes are counterclockwise. An error is returned if any ring isn't closed.
+func MakePolygon(polygons ...[]Part) (Polygon, error) {
+	var parts []Part
+	for _, rings := range polygons {
+		for i, ring := range rings {
+			if len(ring) < 4 {
+				return Polygon{}, fmt.Errorf("expecting at least 4 points in ring but have %d", len(ring))
+			} else if ring[0].Point != ring[len(ring)-1].Point {
+				return Polygon{}, fmt.Errorf("ring is not closed")
+			}
+
+			if outer := i == 0; ring.IsClockwise() != outer {
+				ring = reversePart(ring)
+			}
+			parts = append(parts, ring)
+		}
+	}
+
+	return Polygon{
+		BoundingBox: boxedParts(parts),
+		Parts:       parts,
+	}, nil
+}
+
+func reversePart(part Part) Part {
+	out := make(Part, len(part))
+	for i, p := range part {
+		out[len(part)-1-i] = p
+	}
+	return out
+}
+
 // Type is PolygonType.
 func (p Polygon) Type() ShapeType {
 	return PolygonType
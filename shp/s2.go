@@ -0,0 +1,67 @@
+package shp
+
+import (
+	"github.com/golang/geo/s2"
+)
+
+// S2Point converts the point to an s2.Point, where X is longitude and Y is latitude.
+func (p Point) S2Point() s2.Point {
+	return s2.PointFromLatLng(pointToLatLng(p))
+}
+
+// S2Polylines converts each part of the polyline to an s2.Polyline, where X is longitude and Y is latitude.
+// Empty parts are omitted.
+func (p Polyline) S2Polylines() []*s2.Polyline {
+	out := make([]*s2.Polyline, 0, len(p.Parts))
+	for _, part := range p.Parts {
+		if len(part) == 0 {
+			continue
+		}
+
+		line := make(s2.Polyline, len(part))
+		for i, point := range part {
+			line[i] = point.S2Point()
+		}
+		out = append(out, &line)
+	}
+	return out
+}
+
+// S2Polygon converts the polygon to an s2.Polygon, where X is longitude and Y is latitude.
+// Shapefile outer rings are clockwise and holes are counterclockwise, whereas S2 expects the interior
+// to be on the left of each loop, so every ring is reversed. Rings with fewer than 3 distinct points are omitted.
+func (p Polygon) S2Polygon() *s2.Polygon {
+	loops := make([]*s2.Loop, 0, len(p.Parts))
+	for _, part := range p.Parts {
+		points := ringToS2Points(part, true)
+		if len(points) < 3 {
+			continue
+		}
+		loops = append(loops, s2.LoopFromPoints(points))
+	}
+	return s2.PolygonFromOrientedLoops(loops)
+}
+
+// Covering returns the S2 cells that cover the shape, where X is longitude and Y is latitude.
+// Polylines are covered part by part, and the results are combined.
+// If coverer is nil, s2.NewRegionCoverer is used.
+func Covering(shape Shape, coverer *s2.RegionCoverer) s2.CellUnion {
+	if coverer == nil {
+		coverer = s2.NewRegionCoverer()
+	}
+
+	switch s := shape.(type) {
+	case Point:
+		return coverer.Covering(s.S2Point())
+	case Polyline:
+		var unions []s2.CellUnion
+		for _, line := range s.S2Polylines() {
+			unions = append(unions, coverer.Covering(line))
+		}
+		return s2.CellUnionFromUnion(unions...)
+	case Polygon:
+		return coverer.Covering(s.S2Polygon())
+	default:
+		return nil
+	}
+}
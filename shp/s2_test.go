@@ -0,0 +1,76 @@
+package shp_test
+
+import (
+	"testing"
+
+	"github.com/everystreet/go-shapefile/shp"
+	"github.com/golang/geo/s2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestS2Polygon(t *testing.T) {
+	// 10x10 degree square with a 2x2 hole.
+	p := shp.Polygon{
+		Parts: []shp.Part{
+			ring(0, 0, 0, 10, 10, 10, 10, 0, 0, 0),
+			ring(4, 4, 6, 4, 6, 6, 4, 6, 4, 4),
+		},
+	}
+
+	polygon := p.S2Polygon()
+	require.NoError(t, polygon.Validate())
+	require.Equal(t, 2, polygon.NumLoops())
+	require.True(t, polygon.ContainsPoint(shp.MakePoint(1, 1).S2Point()))
+	require.False(t, polygon.ContainsPoint(shp.MakePoint(5, 5).S2Point()))
+	require.False(t, polygon.ContainsPoint(shp.MakePoint(20, 20).S2Point()))
+	require.InEpsilon(t, p.GeodesicArea(), polygon.Area()*6371008.8*6371008.8, 1e-6)
+
+	covering := shp.Covering(p, &s2.RegionCoverer{MaxLevel: 12, MaxCells: 16})
+	require.NotEmpty(t, covering)
+	require.LessOrEqual(t, len(covering), 16)
+	require.True(t, covering.ContainsPoint(shp.MakePoint(1, 1).S2Point()))
+	require.False(t, covering.ContainsPoint(shp.MakePoint(20, 20).S2Point()))
+
+	t.Run("repeated points", func(t *testing.T) {
+		p := shp.Polygon{
+			Parts: []shp.Part{
+				ring(0, 0, 0, 10, 0, 10, 10, 10, 10, 0, 0, 0, 0, 0),
+				ring(4, 4, 4, 4, 4, 6, 4, 4),
+			},
+		}
+
+		polygon := p.S2Polygon()
+		require.NoError(t, polygon.Validate())
+		require.Equal(t, 1, polygon.NumLoops())
+		require.Equal(t, 4, polygon.Loop(0).NumVertices())
+	})
+}
+
+func TestS2Polylines(t *testing.T) {
+	p := shp.Polyline{
+		Parts: []shp.Part{
+			ring(0, 0, 1, 0, 1, 1),
+			ring(10, 10, 11, 10),
+		},
+	}
+
+	lines := p.S2Polylines()
+	require.Len(t, lines, 2)
+	require.Len(t, *lines[0], 3)
+	require.InEpsilon(t, p.GeodesicLength(), (lines[0].Length()+lines[1].Length()).Radians()*6371008.8, 1e-6)
+
+	covering := shp.Covering(p, nil)
+	require.True(t, covering.ContainsPoint(shp.MakePoint(0.5, 0).S2Point()))
+	require.True(t, covering.ContainsPoint(shp.MakePoint(10.5, 10).S2Point()))
+}
+
+func TestS2Point(t *testing.T) {
+	p := shp.MakePoint(-0.1275, 51.5072)
+
+	ll := s2.LatLngFromPoint(p.S2Point())
+	require.InDelta(t, 51.5072, ll.Lat.Degrees(), 1e-9)
+	require.InDelta(t, -0.1275, ll.Lng.Degrees(), 1e-9)
+
+	covering := shp.Covering(p, &s2.RegionCoverer{MinLevel: 10, MaxLevel: 10, MaxCells: 1})
+	require.Equal(t, s2.CellUnion{s2.CellIDFromLatLng(ll).Parent(10)}, covering)
+}
@@ -133,6 +133,17 @@ func (s *Scanner) decodeRecord(rec record, conf config) {
 		s.setErr(NewError(err, rec.number))
 		return
 	}
+
+	if conf.transform != nil {
+		shape = transformShape(shape, conf.transform)
+	}
+
+	if conf.simplifier != nil {
+		var ok bool
+		if shape, ok = conf.simplifier.Simplify(shape); !ok {
+			return
+		}
+	}
 	s.shapesCh <- shape
 }
 
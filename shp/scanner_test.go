@@ -1,6 +1,7 @@
 package shp_test
 
 import (
+	"math"
 	"os"
 	"path/filepath"
 	"testing"
@@ -63,3 +64,72 @@ func TestScan(t *testing.T) {
 
 	require.NoError(t, r.Close())
 }
+
+func TestScanWithTransform(t *testing.T) {
+	r, err := os.Open(filepath.Join("../testdata", "ne_110m_admin_0_sovereignty.shp"))
+	require.NoError(t, err)
+
+	s := shp.NewScanner(r, shp.PointPrecision(6), shp.Transform(func(x, y float64) (float64, float64) {
+		return x / 2, -y / 2
+	}))
+
+	h, err := s.Header()
+	require.NoError(t, err)
+
+	require.Equal(t, shp.BoundingBox{
+		MinX: -90,
+		MinY: -41.822565,
+		MaxX: 90,
+		MaxY: 45,
+	}, h.BoundingBox)
+
+	err = s.Scan()
+	require.NoError(t, err)
+
+	v, err := s.Validator()
+	require.NoError(t, err)
+
+	for {
+		shape := s.Shape()
+		if shape == nil {
+			break
+		}
+		require.NoError(t, shape.Validate(v))
+
+		p := shape.(shp.Polygon)
+		require.Equal(t, shp.Shapes{p}.BoundingBox(), p.BoundingBox)
+		require.True(t, p.BoundingBox.MaxX <= 90)
+	}
+
+	require.NoError(t, s.Err())
+	require.NoError(t, r.Close())
+}
+
+func TestScanWithCurvedTransform(t *testing.T) {
+	r, err := os.Open(filepath.Join("../testdata", "ne_110m_admin_0_sovereignty.shp"))
+	require.NoError(t, err)
+	defer r.Close()
+
+	// The corners of the file box are at ±180°, where the offset is smallest
+	s := shp.NewScanner(r, shp.Transform(func(x, y float64) (float64, float64) {
+		return x / 2, y/2 + 40*math.Cos(x*math.Pi/180)
+	}))
+
+	h, err := s.Header()
+	require.NoError(t, err)
+	require.InDelta(t, 81.8226, h.BoundingBox.MaxY, 0.0001)
+
+	require.NoError(t, s.Scan())
+
+	v, err := s.Validator()
+	require.NoError(t, err)
+
+	for {
+		shape := s.Shape()
+		if shape == nil {
+			break
+		}
+		require.NoError(t, shape.Validate(v))
+	}
+	require.NoError(t, s.Err())
+}
@@ -69,6 +69,9 @@ type Shape interface {
 	RecordNumber() uint32
 	Validate(Validator) error
 	GeoJSONFeature() *geojson.Feature
+	MarshalWKT() ([]byte, error)
+	MarshalWKB() ([]byte, error)
+	MarshalEWKB(srid uint32) ([]byte, error)
 	points() []r2.Point
 }
 
@@ -82,11 +85,5 @@ func (s Shapes) BoundingBox() BoundingBox {
 		points = append(points, shape.points()...)
 	}
 
-	rect := r2.RectFromPoints(points...)
-	return BoundingBox{
-		MinX: rect.X.Lo,
-		MinY: rect.Y.Lo,
-		MaxX: rect.X.Hi,
-		MaxY: rect.Y.Hi,
-	}
+	return boxFromPoints(points)
 }
@@ -0,0 +1,382 @@
+package shp
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+)
+
+// SimplifyMethod is an algorithm used to simplify lines.
+type SimplifyMethod uint
+
+// Simplification algorithms.
+const (
+	// DouglasPeucker removes points that are within the tolerance distance of a simplified line.
+	DouglasPeucker SimplifyMethod = iota
+
+	// Visvalingam removes points whose effective area, the area of the triangle formed with its neighbours,
+	// is smaller than the tolerance.
+	Visvalingam
+)
+
+// Simplifier reduces the number of points in Polyline and Polygon shapes.
+// Points are never simplified.
+type Simplifier struct {
+	Method SimplifyMethod
+
+	// Tolerance is a distance for DouglasPeucker, and an area for Visvalingam,
+	// in the units of the shape coordinates.
+	Tolerance float64
+
+	// PreserveTopology prevents polygon rings from being reduced to fewer than 4 points,
+	// and prevents the simplified parts of a shape from intersecting each other or themselves.
+	// Parts that break these rules are simplified again with a smaller tolerance, and are left untouched
+	// if that still fails. Intersections that are already present in the input are allowed.
+	PreserveTopology bool
+}
+
+// maxSimplifyAttempts is the number of times the tolerance of a part is halved when simplification breaks topology.
+const maxSimplifyAttempts = 8
+
+// Simplify returns a simplified copy of the shape.
+// false is returned if every part of the shape collapses.
+func (s Simplifier) Simplify(shape Shape) (Shape, bool) {
+	switch shape := shape.(type) {
+	case Polyline:
+		return s.Polyline(shape)
+	case Polygon:
+		return s.Polygon(shape)
+	default:
+		return shape, true
+	}
+}
+
+// Polyline returns a simplified copy of the polyline.
+// The end points of each part are always retained, so false is only returned if no part has at least 2 points.
+func (s Simplifier) Polyline(p Polyline) (Polyline, bool) {
+	parts := s.parts(p.Parts, 2)
+	if len(parts) == 0 {
+		return Polyline{}, false
+	}
+
+	return Polyline{
+		BoundingBox: boxedParts(parts),
+		Parts:       parts,
+		number:      p.number,
+	}, true
+}
+
+// Polygon returns a simplified copy of the polygon.
+// Unless PreserveTopology is set, rings that collapse to fewer than 4 points are removed,
+// along with the holes they contain. false is returned if every ring is removed.
+func (s Simplifier) Polygon(p Polygon) (Polygon, bool) {
+	parts := s.parts(p.Parts, 4)
+	if len(parts) == 0 {
+		return Polygon{}, false
+	}
+
+	return Polygon{
+		BoundingBox: boxedParts(parts),
+		Parts:       parts,
+		number:      p.number,
+	}, true
+}
+
+func (s Simplifier) parts(parts []Part, minPoints int) []Part {
+	out := make([]Part, len(parts))
+	for i, part := range parts {
+		out[i] = s.part(part, s.Tolerance)
+	}
+
+	if !s.PreserveTopology {
+		// Holes are removed along with the outer ring that contains them
+		var shell []int
+		if minPoints == 4 {
+			shell = shells(parts)
+		}
+
+		kept := make([]Part, 0, len(out))
+		for i, part := range out {
+			if len(part) < minPoints || (shell != nil && len(out[shell[i]]) < minPoints) {
+				continue
+			}
+			kept = append(kept, part)
+		}
+		return kept
+	}
+
+	// Parts that intersect to begin with, such as crossing roads, can't be made any worse
+	closed := minPoints == 4
+	existing := intersectingParts(parts, closed)
+
+	tolerances := make([]float64, len(parts))
+	for i := range tolerances {
+		tolerances[i] = s.Tolerance
+	}
+
+	for attempt := 0; ; attempt++ {
+		invalid := make(map[int]bool)
+		for i, part := range out {
+			if len(part) < minPoints && len(part) < len(parts[i]) {
+				invalid[i] = true
+			}
+		}
+
+		for pair := range intersectingParts(out, closed) {
+			if !existing[pair] {
+				invalid[pair[0]], invalid[pair[1]] = true, true
+			}
+		}
+
+		if len(invalid) == 0 {
+			return out
+		}
+
+		// The input never intersects in new ways, so reverting invalid parts always terminates
+		for i := range invalid {
+			if attempt < maxSimplifyAttempts {
+				tolerances[i] /= 2
+				out[i] = s.part(parts[i], tolerances[i])
+			} else {
+				out[i] = copyPart(parts[i])
+			}
+		}
+	}
+}
+
+func (s Simplifier) part(part Part, tolerance float64) Part {
+	if len(part) < 3 || tolerance <= 0 {
+		return copyPart(part)
+	}
+
+	var keep []bool
+	switch s.Method {
+	case Visvalingam:
+		keep = visvalingam(part, tolerance)
+	default:
+		keep = make([]bool, len(part))
+		keep[0], keep[len(part)-1] = true, true
+		douglasPeucker(part, 0, len(part)-1, tolerance, keep)
+	}
+
+	out := make(Part, 0, len(part))
+	for i, k := range keep {
+		if k {
+			out = append(out, MakePoint(part[i].X, part[i].Y))
+		}
+	}
+	return out
+}
+
+func douglasPeucker(part Part, first, last int, tolerance float64, keep []bool) {
+	if last-first < 2 {
+		return
+	}
+
+	index := -1
+	max := tolerance
+	for i := first + 1; i < last; i++ {
+		if d := segmentDistance(part[i], part[first], part[last]); d > max {
+			index, max = i, d
+		}
+	}
+
+	if index == -1 {
+		return
+	}
+
+	keep[index] = true
+	douglasPeucker(part, first, index, tolerance, keep)
+	douglasPeucker(part, index, last, tolerance, keep)
+}
+
+// segmentDistance returns the distance from p to the line segment between a and b.
+func segmentDistance(p, a, b Point) float64 {
+	ab := b.Sub(a.Point)
+	length := ab.Dot(ab)
+	if length == 0 {
+		return p.Distance(a)
+	}
+
+	t := math.Max(0, math.Min(1, p.Sub(a.Point).Dot(ab)/length))
+	return p.Sub(a.Add(ab.Mul(t))).Norm()
+}
+
+func visvalingam(part Part, tolerance float64) []bool {
+	n := len(part)
+	prev := make([]int, n)
+	next := make([]int, n)
+	keep := make([]bool, n)
+
+	h := make(triangleHeap, 0, n)
+	items := make([]*triangle, n)
+	for i := range part {
+		prev[i], next[i], keep[i] = i-1, i+1, true
+		if i > 0 && i < n-1 {
+			items[i] = &triangle{index: i, area: triangleArea(part[i-1], part[i], part[i+1]), pos: len(h)}
+			h = append(h, items[i])
+		}
+	}
+	heap.Init(&h)
+
+	var min float64
+	for h.Len() > 0 {
+		t := heap.Pop(&h).(*triangle)
+
+		// Ensure that a point is never removed before a point that had a smaller area
+		area := math.Max(t.area, min)
+		if area >= tolerance {
+			break
+		}
+		min = area
+		keep[t.index] = false
+
+		p, nx := prev[t.index], next[t.index]
+		next[p], prev[nx] = nx, p
+
+		for _, i := range []int{p, nx} {
+			if items[i] == nil || !keep[i] {
+				continue
+			}
+			items[i].area = triangleArea(part[prev[i]], part[i], part[next[i]])
+			heap.Fix(&h, items[i].pos)
+		}
+	}
+	return keep
+}
+
+func triangleArea(a, b, c Point) float64 {
+	return math.Abs((b.X-a.X)*(c.Y-a.Y)-(c.X-a.X)*(b.Y-a.Y)) / 2
+}
+
+type triangle struct {
+	index int
+	area  float64
+	pos   int
+}
+
+type triangleHeap []*triangle
+
+func (h triangleHeap) Len() int           { return len(h) }
+func (h triangleHeap) Less(i, j int) bool { return h[i].area < h[j].area }
+
+func (h triangleHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].pos, h[j].pos = i, j
+}
+
+func (h *triangleHeap) Push(x interface{}) {
+	t := x.(*triangle)
+	t.pos = len(*h)
+	*h = append(*h, t)
+}
+
+func (h *triangleHeap) Pop() interface{} {
+	old := *h
+	t := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return t
+}
+
+// intersectingParts returns the indexes of each pair of parts with intersecting non-adjacent segments,
+// where a part that intersects itself is paired with itself.
+// If closed is true, the first and last segments of each part are considered to be adjacent.
+func intersectingParts(parts []Part, closed bool) map[[2]int]bool {
+	type segment struct {
+		part, index int
+		a, b        Point
+		minX, maxX  float64
+	}
+
+	var segs []segment
+	for i, part := range parts {
+		for j := 1; j < len(part); j++ {
+			a, b := part[j-1], part[j]
+			segs = append(segs, segment{
+				part:  i,
+				index: j,
+				a:     a,
+				b:     b,
+				minX:  math.Min(a.X, b.X),
+				maxX:  math.Max(a.X, b.X),
+			})
+		}
+	}
+
+	sort.Slice(segs, func(i, j int) bool {
+		return segs[i].minX < segs[j].minX
+	})
+
+	pairs := make(map[[2]int]bool)
+	for i, s1 := range segs {
+		for _, s2 := range segs[i+1:] {
+			if s2.minX > s1.maxX {
+				break
+			}
+
+			pair := [2]int{s1.part, s2.part}
+			if pair[0] > pair[1] {
+				pair[0], pair[1] = pair[1], pair[0]
+			}
+
+			if pairs[pair] {
+				continue
+			} else if s1.part == s2.part {
+				n := len(parts[s1.part]) - 1
+				d := s1.index - s2.index
+				if d == 1 || d == -1 || (closed && (d == n-1 || d == 1-n)) {
+					continue
+				}
+			}
+
+			if segmentsIntersect(s1.a, s1.b, s2.a, s2.b) {
+				pairs[pair] = true
+			}
+		}
+	}
+	return pairs
+}
+
+func segmentsIntersect(p1, p2, p3, p4 Point) bool {
+	d1 := orientation(p3, p4, p1)
+	d2 := orientation(p3, p4, p2)
+	d3 := orientation(p1, p2, p3)
+	d4 := orientation(p1, p2, p4)
+
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+
+	return (d1 == 0 && onSegment(p3, p4, p1)) ||
+		(d2 == 0 && onSegment(p3, p4, p2)) ||
+		(d3 == 0 && onSegment(p1, p2, p3)) ||
+		(d4 == 0 && onSegment(p1, p2, p4))
+}
+
+func orientation(a, b, c Point) float64 {
+	return (b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)
+}
+
+func onSegment(a, b, p Point) bool {
+	return math.Min(a.X, b.X) <= p.X && p.X <= math.Max(a.X, b.X) &&
+		math.Min(a.Y, b.Y) <= p.Y && p.Y <= math.Max(a.Y, b.Y)
+}
+
+func copyPart(part Part) Part {
+	out := make(Part, len(part))
+	for i, p := range part {
+		out[i] = MakePoint(p.X, p.Y)
+	}
+	return out
+}
+
+// boxedParts returns the bounding box of the parts, and sets it as the box of each point.
+func boxedParts(parts []Part) BoundingBox {
+	box := boxFromPoints(Polyline{Parts: parts}.points())
+	for _, part := range parts {
+		for i := range part {
+			part[i].box = &box
+		}
+	}
+	return box
+}
@@ -0,0 +1,138 @@
+package shp_test
+
+import (
+	"testing"
+
+	"github.com/everystreet/go-shapefile/shp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimplifyPolyline(t *testing.T) {
+	p := shp.Polyline{
+		Parts: []shp.Part{
+			ring(0, 0, 1, 0.1, 2, -0.1, 3, 5, 4, 6, 5, 7, 6, 8.1, 7, 9),
+		},
+	}
+
+	tests := []struct {
+		name     string
+		s        shp.Simplifier
+		expected shp.Part
+	}{
+		{
+			"douglas-peucker",
+			shp.Simplifier{Method: shp.DouglasPeucker, Tolerance: 0.5},
+			ring(0, 0, 2, -0.1, 3, 5, 7, 9),
+		},
+		{
+			"visvalingam",
+			shp.Simplifier{Method: shp.Visvalingam, Tolerance: 0.5},
+			ring(0, 0, 2, -0.1, 3, 5, 7, 9),
+		},
+		{
+			"zero tolerance",
+			shp.Simplifier{},
+			p.Parts[0],
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			simplified, ok := tt.s.Polyline(p)
+			require.True(t, ok)
+			require.Len(t, simplified.Parts, 1)
+			pointsEqual(t, tt.expected, simplified.Parts[0])
+			require.Equal(t, shp.BoundingBox{MinX: 0, MinY: -0.1, MaxX: 7, MaxY: 9}, simplified.BoundingBox)
+		})
+	}
+}
+
+func TestSimplifyPolygon(t *testing.T) {
+	p := shp.Polygon{
+		Parts: []shp.Part{
+			ring(0, 0, 0, 10, 10, 10, 10, 0, 0, 0),
+			ring(4, 4, 4.1, 4.5, 4.2, 4.1, 4, 4),
+		},
+	}
+
+	t.Run("collapsed rings removed", func(t *testing.T) {
+		s := shp.Simplifier{Tolerance: 1}
+		simplified, ok := s.Polygon(p)
+		require.True(t, ok)
+		require.Len(t, simplified.Parts, 1)
+		pointsEqual(t, p.Parts[0], simplified.Parts[0])
+	})
+
+	t.Run("holes removed with outer ring", func(t *testing.T) {
+		// The outer ring collapses, but the hole is still large enough to keep
+		p := shp.Polygon{
+			Parts: []shp.Part{
+				ring(0, 0, 0, 10, 10, 10, 10, 0, 0, 0),
+				ring(0.5, 5, 0.5, 0.5, 9.5, 0.5, 9.5, 9.5, 0.5, 9.5, 0.5, 5),
+			},
+		}
+
+		s := shp.Simplifier{Tolerance: 7.5}
+		simplified, ok := s.Polygon(shp.Polygon{Parts: p.Parts[1:]})
+		require.True(t, ok)
+		require.Len(t, simplified.Parts, 1)
+
+		// Every ring collapses
+		_, ok = s.Polygon(p)
+		require.False(t, ok)
+	})
+
+	t.Run("preserve topology", func(t *testing.T) {
+		s := shp.Simplifier{Tolerance: 1, PreserveTopology: true}
+		simplified, ok := s.Polygon(p)
+		require.True(t, ok)
+		require.Len(t, simplified.Parts, 2)
+		pointsEqual(t, p.Parts[1], simplified.Parts[1])
+	})
+
+	t.Run("no self-intersection", func(t *testing.T) {
+		// Removing the peak of the outer ring would cut through the hole
+		p := shp.Polygon{
+			Parts: []shp.Part{
+				ring(0, 0, 0, 10, 5, 13, 10, 10, 10, 0, 0, 0),
+				ring(4.5, 9.5, 5.5, 9.5, 5, 11.5, 4.5, 9.5),
+			},
+		}
+
+		unsafe := shp.Simplifier{Tolerance: 4}
+		simplified, ok := unsafe.Polygon(p)
+		require.True(t, ok)
+		pointsEqual(t, ring(0, 0, 0, 10, 10, 10, 10, 0, 0, 0), simplified.Parts[0])
+
+		s := shp.Simplifier{Tolerance: 4, PreserveTopology: true}
+		simplified, ok = s.Polygon(p)
+		require.True(t, ok)
+		require.Len(t, simplified.Parts, 2)
+		pointsEqual(t, p.Parts[0], simplified.Parts[0])
+	})
+}
+
+func TestSimplifyExistingIntersections(t *testing.T) {
+	// The first two parts cross, but flattening the third would make it cross the fourth
+	p := shp.Polyline{
+		Parts: []shp.Part{
+			ring(0, 0, 10, 10),
+			ring(0, 10, 10, 0),
+			ring(0, 20, 5, 13, 10, 20),
+			ring(5, 15, 5, 21),
+		},
+	}
+
+	unsafe := shp.Simplifier{Tolerance: 8}
+	simplified, ok := unsafe.Polyline(p)
+	require.True(t, ok)
+	pointsEqual(t, ring(0, 20, 10, 20), simplified.Parts[2])
+
+	s := shp.Simplifier{Tolerance: 8, PreserveTopology: true}
+	simplified, ok = s.Polyline(p)
+	require.True(t, ok)
+	require.Len(t, simplified.Parts, 4)
+	for i, part := range p.Parts {
+		pointsEqual(t, part, simplified.Parts[i])
+	}
+}
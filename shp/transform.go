@@ -0,0 +1,96 @@
+package shp
+
+import "github.com/golang/geo/r2"
+
+// TransformShape returns a copy of the shape, with the transform function applied to each point.
+// The bounding box of the copy is calculated from the transformed points.
+func TransformShape(shape Shape, fn TransformFunc) Shape {
+	switch s := shape.(type) {
+	case Polyline:
+		s.Parts = copyParts(s.Parts)
+		return transformShape(s, fn)
+	case Polygon:
+		s.Parts = copyParts(s.Parts)
+		return transformShape(s, fn)
+	default:
+		return transformShape(shape, fn)
+	}
+}
+
+// transformShape applies the transform function to each point in the shape.
+// Parts are updated in place.
+func transformShape(shape Shape, fn TransformFunc) Shape {
+	switch s := shape.(type) {
+	case Point:
+		s.X, s.Y = fn(s.X, s.Y)
+		return s
+	case Polyline:
+		return transformPolyline(s, fn)
+	case Polygon:
+		return Polygon(transformPolyline(Polyline(s), fn))
+	default:
+		return shape
+	}
+}
+
+func transformPolyline(p Polyline, fn TransformFunc) Polyline {
+	for _, part := range p.Parts {
+		for i := range part {
+			part[i].X, part[i].Y = fn(part[i].X, part[i].Y)
+		}
+	}
+
+	box := boxFromPoints(p.points())
+	for _, part := range p.Parts {
+		for i := range part {
+			part[i].box = &box
+		}
+	}
+
+	p.BoundingBox = box
+	return p
+}
+
+func copyParts(parts []Part) []Part {
+	out := make([]Part, len(parts))
+	for i, part := range parts {
+		out[i] = copyPart(part)
+	}
+	return out
+}
+
+// transformBox returns the box that encompasses the transformed edges of b. The header box is decoded before
+// any records, so its points are sampled along each edge rather than taken from the shapes. This is exact for
+// continuous transforms, such as reprojections, up to the spacing of the samples.
+func transformBox(b BoundingBox, fn TransformFunc) BoundingBox {
+	const samples = 64
+
+	points := make([]r2.Point, 0, 4*samples)
+	for i := 0; i < samples; i++ {
+		t := float64(i) / samples
+		x := b.MinX + t*(b.MaxX-b.MinX)
+		y := b.MinY + t*(b.MaxY-b.MinY)
+
+		for _, c := range [][2]float64{
+			{x, b.MinY},
+			{b.MaxX, y},
+			{b.MaxX - (x - b.MinX), b.MaxY},
+			{b.MinX, b.MaxY - (y - b.MinY)},
+		} {
+			var p r2.Point
+			p.X, p.Y = fn(c[0], c[1])
+			points = append(points, p)
+		}
+	}
+	return boxFromPoints(points)
+}
+
+func boxFromPoints(points []r2.Point) BoundingBox {
+	rect := r2.RectFromPoints(points...)
+	return BoundingBox{
+		MinX: rect.X.Lo,
+		MinY: rect.Y.Lo,
+		MaxX: rect.X.Hi,
+		MaxY: rect.Y.Hi,
+	}
+}
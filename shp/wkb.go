@@ -0,0 +1,335 @@
+package shp
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// WKB geometry types.
+const (
+	wkbPoint           uint32 = 1
+	wkbLineString      uint32 = 2
+	wkbPolygon         uint32 = 3
+	wkbMultiLineString uint32 = 5
+	wkbMultiPolygon    uint32 = 6
+)
+
+// EWKB flags, stored in the high bits of the geometry type.
+const (
+	ewkbZ    uint32 = 0x80000000
+	ewkbM    uint32 = 0x40000000
+	ewkbSRID uint32 = 0x20000000
+)
+
+// MarshalWKB encodes the point as a little-endian Well-Known Binary Point.
+func (p Point) MarshalWKB() ([]byte, error) {
+	return p.appendWKB(nil, nil), nil
+}
+
+// MarshalEWKB encodes the point as a little-endian Extended Well-Known Binary Point, as used by PostGIS,
+// including the spatial reference system identifier.
+func (p Point) MarshalEWKB(srid uint32) ([]byte, error) {
+	return p.appendWKB(nil, &srid), nil
+}
+
+// MarshalWKB encodes the polyline as a little-endian Well-Known Binary MultiLineString.
+func (p Polyline) MarshalWKB() ([]byte, error) {
+	return p.appendWKB(nil, nil), nil
+}
+
+// MarshalEWKB encodes the polyline as a little-endian Extended Well-Known Binary MultiLineString,
+// as used by PostGIS, including the spatial reference system identifier.
+func (p Polyline) MarshalEWKB(srid uint32) ([]byte, error) {
+	return p.appendWKB(nil, &srid), nil
+}
+
+// MarshalWKB encodes the polygon as a little-endian Well-Known Binary Polygon if it has a single outer ring,
+// or a MultiPolygon otherwise. Holes are assigned to outer rings using Polygons.
+func (p Polygon) MarshalWKB() ([]byte, error) {
+	return p.appendWKB(nil, nil), nil
+}
+
+// MarshalEWKB encodes the polygon as a little-endian Extended Well-Known Binary Polygon or MultiPolygon,
+// as used by PostGIS, including the spatial reference system identifier.
+func (p Polygon) MarshalEWKB(srid uint32) ([]byte, error) {
+	return p.appendWKB(nil, &srid), nil
+}
+
+func (p Point) appendWKB(buf []byte, srid *uint32) []byte {
+	buf = appendWKBHeader(buf, wkbPoint, srid)
+	return appendPoint(buf, p)
+}
+
+func (p Polyline) appendWKB(buf []byte, srid *uint32) []byte {
+	buf = appendWKBHeader(buf, wkbMultiLineString, srid)
+	buf = appendUint32(buf, uint32(len(p.Parts)))
+	for _, part := range p.Parts {
+		buf = appendWKBHeader(buf, wkbLineString, nil)
+		buf = appendWKBPart(buf, part)
+	}
+	return buf
+}
+
+func (p Polygon) appendWKB(buf []byte, srid *uint32) []byte {
+	polygons := p.Polygons()
+	if len(polygons) < 2 {
+		buf = appendWKBHeader(buf, wkbPolygon, srid)
+		return appendWKBRings(buf, polygonRings(p, polygons))
+	}
+
+	buf = appendWKBHeader(buf, wkbMultiPolygon, srid)
+	buf = appendUint32(buf, uint32(len(polygons)))
+	for _, polygon := range polygons {
+		buf = appendWKBHeader(buf, wkbPolygon, nil)
+		buf = appendWKBRings(buf, polygon.Parts)
+	}
+	return buf
+}
+
+// polygonRings returns the rings of a polygon with at most one outer ring, which comes first
+// even if a hole is stored before it.
+func polygonRings(p Polygon, polygons []Polygon) []Part {
+	if len(polygons) == 1 {
+		return polygons[0].Parts
+	}
+	return p.Parts
+}
+
+func appendWKBHeader(buf []byte, typ uint32, srid *uint32) []byte {
+	buf = append(buf, 1) // Little endian
+	if srid == nil {
+		return appendUint32(buf, typ)
+	}
+	buf = appendUint32(buf, typ|ewkbSRID)
+	return appendUint32(buf, *srid)
+}
+
+func appendWKBRings(buf []byte, rings []Part) []byte {
+	buf = appendUint32(buf, uint32(len(rings)))
+	for _, ring := range rings {
+		buf = appendWKBPart(buf, ring)
+	}
+	return buf
+}
+
+func appendWKBPart(buf []byte, part Part) []byte {
+	buf = appendUint32(buf, uint32(len(part)))
+	for _, p := range part {
+		buf = appendPoint(buf, p)
+	}
+	return buf
+}
+
+func appendPoint(buf []byte, p Point) []byte {
+	buf = appendUint64(buf, math.Float64bits(p.X))
+	return appendUint64(buf, math.Float64bits(p.Y))
+}
+
+func appendUint32(buf []byte, v uint32) []byte {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], v)
+	return append(buf, b[:]...)
+}
+
+func appendUint64(buf []byte, v uint64) []byte {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], v)
+	return append(buf, b[:]...)
+}
+
+// ParseWKB creates a shape from Well-Known Binary, or Extended Well-Known Binary in which case the SRID is ignored.
+// Geometry types are converted in the same way as ParseWKT.
+func ParseWKB(data []byte) (Shape, error) {
+	shape, _, err := ParseEWKB(data)
+	return shape, err
+}
+
+// ParseEWKB creates a shape from Extended Well-Known Binary, returning the spatial reference system identifier,
+// which is 0 if the data doesn't include one. Plain Well-Known Binary is also accepted.
+func ParseEWKB(data []byte) (Shape, uint32, error) {
+	r := wkbReader{buf: data}
+
+	shape, srid, err := r.geometry()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to parse WKB: %w", err)
+	} else if len(r.buf) > 0 {
+		return nil, 0, fmt.Errorf("failed to parse WKB: %d unexpected bytes after geometry", len(r.buf))
+	}
+	return shape, srid, nil
+}
+
+type wkbReader struct {
+	buf   []byte
+	order binary.ByteOrder
+}
+
+func (r *wkbReader) geometry() (Shape, uint32, error) {
+	typ, srid, err := r.header()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	switch typ {
+	case wkbPoint:
+		p, err := r.point()
+		return p, srid, err
+	case wkbLineString:
+		part, err := r.part()
+		if err != nil {
+			return nil, 0, err
+		}
+		return MakePolyline(part), srid, nil
+	case wkbMultiLineString:
+		var parts []Part
+		if err := r.collection(wkbLineString, func() error {
+			part, err := r.part()
+			parts = append(parts, part)
+			return err
+		}); err != nil {
+			return nil, 0, err
+		}
+		return MakePolyline(parts...), srid, nil
+	case wkbPolygon:
+		rings, err := r.rings()
+		if err != nil {
+			return nil, 0, err
+		}
+
+		var polygons [][]Part
+		if len(rings) > 0 {
+			polygons = [][]Part{rings}
+		}
+
+		p, err := MakePolygon(polygons...)
+		return p, srid, err
+	case wkbMultiPolygon:
+		var polygons [][]Part
+		if err := r.collection(wkbPolygon, func() error {
+			rings, err := r.rings()
+			polygons = append(polygons, rings)
+			return err
+		}); err != nil {
+			return nil, 0, err
+		}
+
+		p, err := MakePolygon(polygons...)
+		return p, srid, err
+	default:
+		return nil, 0, fmt.Errorf("unsupported geometry type %d", typ)
+	}
+}
+
+// header reads the byte order and geometry type, and the SRID if present.
+func (r *wkbReader) header() (uint32, uint32, error) {
+	if len(r.buf) < 1 {
+		return 0, 0, fmt.Errorf("missing byte order")
+	}
+
+	switch r.buf[0] {
+	case 0:
+		r.order = binary.BigEndian
+	case 1:
+		r.order = binary.LittleEndian
+	default:
+		return 0, 0, fmt.Errorf("invalid byte order %d", r.buf[0])
+	}
+	r.buf = r.buf[1:]
+
+	typ, err := r.uint32()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if typ&(ewkbZ|ewkbM) != 0 || typ&^ewkbSRID > 1000 {
+		return 0, 0, fmt.Errorf("Z and M coordinates are not supported")
+	}
+
+	var srid uint32
+	if typ&ewkbSRID != 0 {
+		if srid, err = r.uint32(); err != nil {
+			return 0, 0, err
+		}
+	}
+	return typ &^ ewkbSRID, srid, nil
+}
+
+// collection reads a count, followed by that many geometries of the specified type,
+// calling fn to read the body of each.
+func (r *wkbReader) collection(typ uint32, fn func() error) error {
+	n, err := r.uint32()
+	if err != nil {
+		return err
+	}
+
+	for i := uint32(0); i < n; i++ {
+		if t, _, err := r.header(); err != nil {
+			return err
+		} else if t != typ {
+			return fmt.Errorf("expecting geometry type %d but have %d", typ, t)
+		}
+
+		if err := fn(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *wkbReader) rings() ([]Part, error) {
+	n, err := r.uint32()
+	if err != nil {
+		return nil, err
+	}
+
+	// Each ring has at least a 4 byte point count
+	if uint64(n) > uint64(len(r.buf))/4 {
+		return nil, fmt.Errorf("expecting %d bytes but only have %d", uint64(n)*4, len(r.buf))
+	}
+
+	rings := make([]Part, n)
+	for i := range rings {
+		if rings[i], err = r.part(); err != nil {
+			return nil, err
+		}
+	}
+	return rings, nil
+}
+
+func (r *wkbReader) part() (Part, error) {
+	n, err := r.uint32()
+	if err != nil {
+		return nil, err
+	} else if uint64(n) > uint64(len(r.buf))/16 {
+		return nil, fmt.Errorf("expecting %d bytes but only have %d", uint64(n)*16, len(r.buf))
+	}
+
+	part := make(Part, n)
+	for i := range part {
+		if part[i], err = r.point(); err != nil {
+			return nil, err
+		}
+	}
+	return part, nil
+}
+
+func (r *wkbReader) point() (Point, error) {
+	if len(r.buf) < 16 {
+		return Point{}, fmt.Errorf("expecting 16 bytes but only have %d", len(r.buf))
+	}
+
+	x := math.Float64frombits(r.order.Uint64(r.buf[0:8]))
+	y := math.Float64frombits(r.order.Uint64(r.buf[8:16]))
+	r.buf = r.buf[16:]
+	return MakePoint(x, y), nil
+}
+
+func (r *wkbReader) uint32() (uint32, error) {
+	if len(r.buf) < 4 {
+		return 0, fmt.Errorf("expecting 4 bytes but only have %d", len(r.buf))
+	}
+
+	v := r.order.Uint32(r.buf[0:4])
+	r.buf = r.buf[4:]
+	return v, nil
+}
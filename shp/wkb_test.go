@@ -0,0 +1,70 @@
+package shp_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/everystreet/go-shapefile/shp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalWKB(t *testing.T) {
+	wkb, err := shp.MakePoint(1, 2).MarshalWKB()
+	require.NoError(t, err)
+	require.Equal(t, "0101000000000000000000f03f0000000000000040", hex.EncodeToString(wkb))
+
+	ewkb, err := shp.MakePoint(1, 2).MarshalEWKB(4326)
+	require.NoError(t, err)
+	require.Equal(t, "0101000020e6100000000000000000f03f0000000000000040", hex.EncodeToString(ewkb))
+
+	shape, srid, err := shp.ParseEWKB(ewkb)
+	require.NoError(t, err)
+	require.Equal(t, uint32(4326), srid)
+	require.Equal(t, shp.MakePoint(1, 2), shape)
+
+	// Big endian
+	buf, err := hex.DecodeString("00000000013ff00000000000004000000000000000")
+	require.NoError(t, err)
+	shape, err = shp.ParseWKB(buf)
+	require.NoError(t, err)
+	require.Equal(t, shp.MakePoint(1, 2), shape)
+}
+
+func TestWKBRoundTrip(t *testing.T) {
+	outer := ring(0, 0, 0, 10, 10, 10, 10, 0, 0, 0)
+	hole := ring(2, 2, 4, 2, 4, 4, 2, 4, 2, 2)
+	other := ring(20, 0, 20, 1, 21, 1, 21, 0, 20, 0)
+
+	for _, shape := range []shp.Shape{
+		shp.Polyline{Parts: []shp.Part{ring(0, 0, 1, 1), ring(2, 2, 3, 3, 4, 4)}},
+		shp.Polygon{Parts: []shp.Part{outer, hole}},
+		shp.Polygon{Parts: []shp.Part{outer, hole, other}},
+	} {
+		wkb, err := shape.MarshalEWKB(3857)
+		require.NoError(t, err)
+
+		parsed, srid, err := shp.ParseEWKB(wkb)
+		require.NoError(t, err)
+		require.Equal(t, uint32(3857), srid)
+
+		// Compare using WKT, as the parsed shape has bounding boxes
+		expected, err := shape.MarshalWKT()
+		require.NoError(t, err)
+		actual, err := parsed.MarshalWKT()
+		require.NoError(t, err)
+		require.Equal(t, string(expected), string(actual))
+	}
+
+	_, err := shp.ParseWKB([]byte{1, 1, 0, 0})
+	require.Error(t, err)
+
+	// Polygon claiming 2^31-1 rings
+	_, err = shp.ParseWKB([]byte{1, 3, 0, 0, 0, 0xff, 0xff, 0xff, 0x7f})
+	require.Error(t, err)
+
+	// LineString and Polygon claiming 2^32-1 points and rings, which overflow int on 32 bit platforms
+	_, err = shp.ParseWKB([]byte{1, 2, 0, 0, 0, 0xff, 0xff, 0xff, 0xff})
+	require.Error(t, err)
+	_, err = shp.ParseWKB([]byte{1, 3, 0, 0, 0, 0xff, 0xff, 0xff, 0xff})
+	require.Error(t, err)
+}
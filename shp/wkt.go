@@ -0,0 +1,290 @@
+package shp
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// MarshalWKT encodes the point as a Well-Known Text POINT.
+func (p Point) MarshalWKT() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("POINT (")
+	writeWKTPoint(&buf, p)
+	buf.WriteByte(')')
+	return buf.Bytes(), nil
+}
+
+// MarshalWKT encodes the polyline as a Well-Known Text MULTILINESTRING.
+func (p Polyline) MarshalWKT() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("MULTILINESTRING ")
+	writeWKTParts(&buf, p.Parts)
+	return buf.Bytes(), nil
+}
+
+// MarshalWKT encodes the polygon as a Well-Known Text POLYGON if it has a single outer ring,
+// or a MULTIPOLYGON otherwise. Holes are assigned to outer rings using Polygons.
+func (p Polygon) MarshalWKT() ([]byte, error) {
+	var buf bytes.Buffer
+
+	polygons := p.Polygons()
+	if len(polygons) < 2 {
+		buf.WriteString("POLYGON ")
+		writeWKTParts(&buf, polygonRings(p, polygons))
+		return buf.Bytes(), nil
+	}
+
+	buf.WriteString("MULTIPOLYGON (")
+	for i, polygon := range polygons {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		writeWKTParts(&buf, polygon.Parts)
+	}
+	buf.WriteByte(')')
+	return buf.Bytes(), nil
+}
+
+func writeWKTParts(buf *bytes.Buffer, parts []Part) {
+	if len(parts) == 0 {
+		buf.WriteString("EMPTY")
+		return
+	}
+
+	buf.WriteByte('(')
+	for i, part := range parts {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+
+		buf.WriteByte('(')
+		for j, point := range part {
+			if j > 0 {
+				buf.WriteString(", ")
+			}
+			writeWKTPoint(buf, point)
+		}
+		buf.WriteByte(')')
+	}
+	buf.WriteByte(')')
+}
+
+func writeWKTPoint(buf *bytes.Buffer, p Point) {
+	buf.WriteString(strconv.FormatFloat(p.X, 'f', -1, 64))
+	buf.WriteByte(' ')
+	buf.WriteString(strconv.FormatFloat(p.Y, 'f', -1, 64))
+}
+
+// ParseWKT creates a shape from Well-Known Text.
+// POINT is parsed as a Point, LINESTRING and MULTILINESTRING as a Polyline,
+// and POLYGON and MULTIPOLYGON as a Polygon, with outer rings ordered clockwise and holes counterclockwise.
+func ParseWKT(data []byte) (Shape, error) {
+	p := wktParser{tokens: wktTokens(string(data))}
+
+	shape, err := p.geometry()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse WKT: %w", err)
+	} else if tok := p.next(); tok != "" {
+		return nil, fmt.Errorf("failed to parse WKT: unexpected '%s' after geometry", tok)
+	}
+	return shape, nil
+}
+
+type wktParser struct {
+	tokens []string
+}
+
+func (p *wktParser) geometry() (Shape, error) {
+	switch typ := strings.ToUpper(p.next()); typ {
+	case "POINT":
+		if p.empty() {
+			return nil, fmt.Errorf("empty points are not supported")
+		}
+
+		points, err := p.points()
+		if err != nil {
+			return nil, err
+		} else if len(points) != 1 {
+			return nil, fmt.Errorf("expecting 1 coordinate in POINT but have %d", len(points))
+		}
+		return points[0], nil
+	case "LINESTRING", "MULTILINESTRING":
+		var parts []Part
+		var err error
+		switch {
+		case p.empty():
+		case typ == "LINESTRING":
+			var part Part
+			part, err = p.points()
+			parts = []Part{part}
+		default:
+			parts, err = p.parts()
+		}
+
+		if err != nil {
+			return nil, err
+		}
+		return MakePolyline(parts...), nil
+	case "POLYGON", "MULTIPOLYGON":
+		var polygons [][]Part
+		switch {
+		case p.empty():
+		case typ == "POLYGON":
+			parts, err := p.parts()
+			if err != nil {
+				return nil, err
+			}
+			polygons = [][]Part{parts}
+		default:
+			if err := p.expect("("); err != nil {
+				return nil, err
+			}
+
+			for {
+				parts, err := p.parts()
+				if err != nil {
+					return nil, err
+				}
+				polygons = append(polygons, parts)
+
+				if more, err := p.more(); err != nil {
+					return nil, err
+				} else if !more {
+					break
+				}
+			}
+		}
+		return MakePolygon(polygons...)
+	case "":
+		return nil, fmt.Errorf("missing geometry type")
+	default:
+		return nil, fmt.Errorf("unsupported geometry type '%s'", typ)
+	}
+}
+
+// parts parses a parenthesized list of coordinate lists.
+func (p *wktParser) parts() ([]Part, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+
+	var parts []Part
+	for {
+		part, err := p.points()
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
+
+		if more, err := p.more(); err != nil {
+			return nil, err
+		} else if !more {
+			return parts, nil
+		}
+	}
+}
+
+// points parses a parenthesized list of coordinates.
+func (p *wktParser) points() (Part, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+
+	var part Part
+	for {
+		x, err := p.number()
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := p.number()
+		if err != nil {
+			return nil, err
+		}
+		part = append(part, MakePoint(x, y))
+
+		if more, err := p.more(); err != nil {
+			return nil, err
+		} else if !more {
+			return part, nil
+		}
+	}
+}
+
+func (p *wktParser) number() (float64, error) {
+	tok := p.next()
+	f, err := strconv.ParseFloat(tok, 64)
+	if err != nil {
+		return 0, fmt.Errorf("expecting number but have '%s'", tok)
+	}
+	return f, nil
+}
+
+// more consumes either a comma, returning true, or a closing parenthesis, returning false.
+func (p *wktParser) more() (bool, error) {
+	switch tok := p.next(); tok {
+	case ",":
+		return true, nil
+	case ")":
+		return false, nil
+	default:
+		return false, fmt.Errorf("expecting ',' or ')' but have '%s'", tok)
+	}
+}
+
+// empty consumes the EMPTY keyword if it's next.
+func (p *wktParser) empty() bool {
+	if len(p.tokens) > 0 && strings.EqualFold(p.tokens[0], "EMPTY") {
+		p.tokens = p.tokens[1:]
+		return true
+	}
+	return false
+}
+
+func (p *wktParser) expect(tok string) error {
+	if next := p.next(); next != tok {
+		return fmt.Errorf("expecting '%s' but have '%s'", tok, next)
+	}
+	return nil
+}
+
+func (p *wktParser) next() string {
+	if len(p.tokens) == 0 {
+		return ""
+	}
+
+	tok := p.tokens[0]
+	p.tokens = p.tokens[1:]
+	return tok
+}
+
+// wktTokens splits WKT into words, numbers and punctuation.
+func wktTokens(s string) []string {
+	var tokens []string
+	start := -1
+	for i, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == ',':
+			if start >= 0 {
+				tokens = append(tokens, s[start:i])
+				start = -1
+			}
+			tokens = append(tokens, string(r))
+		case unicode.IsSpace(r):
+			if start >= 0 {
+				tokens = append(tokens, s[start:i])
+				start = -1
+			}
+		case start < 0:
+			start = i
+		}
+	}
+
+	if start >= 0 {
+		tokens = append(tokens, s[start:])
+	}
+	return tokens
+}
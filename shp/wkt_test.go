@@ -0,0 +1,97 @@
+package shp_test
+
+import (
+	"testing"
+
+	"github.com/everystreet/go-shapefile/shp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalWKT(t *testing.T) {
+	outer := ring(0, 0, 0, 10, 10, 10, 10, 0, 0, 0)
+	hole := ring(2, 2, 4, 2, 4, 4, 2, 4, 2, 2)
+	other := ring(20, 0, 20, 1.5, 21, 1.5, 21, 0, 20, 0)
+
+	tests := []struct {
+		name  string
+		shape shp.Shape
+		wkt   string
+	}{
+		{
+			"point",
+			shp.MakePoint(1.5, -2),
+			"POINT (1.5 -2)",
+		},
+		{
+			"polyline",
+			shp.Polyline{Parts: []shp.Part{ring(0, 0, 1, 1), ring(2, 2, 3, 3, 4, 4)}},
+			"MULTILINESTRING ((0 0, 1 1), (2 2, 3 3, 4 4))",
+		},
+		{
+			"empty polyline",
+			shp.Polyline{},
+			"MULTILINESTRING EMPTY",
+		},
+		{
+			"polygon",
+			shp.Polygon{Parts: []shp.Part{outer, hole}},
+			"POLYGON ((0 0, 0 10, 10 10, 10 0, 0 0), (2 2, 4 2, 4 4, 2 4, 2 2))",
+		},
+		{
+			"hole before shell",
+			shp.Polygon{Parts: []shp.Part{hole, outer}},
+			"POLYGON ((0 0, 0 10, 10 10, 10 0, 0 0), (2 2, 4 2, 4 4, 2 4, 2 2))",
+		},
+		{
+			"multipolygon",
+			shp.Polygon{Parts: []shp.Part{outer, other, hole}},
+			"MULTIPOLYGON (((0 0, 0 10, 10 10, 10 0, 0 0), (2 2, 4 2, 4 4, 2 4, 2 2)), " +
+				"((20 0, 20 1.5, 21 1.5, 21 0, 20 0)))",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wkt, err := tt.shape.MarshalWKT()
+			require.NoError(t, err)
+			require.Equal(t, tt.wkt, string(wkt))
+
+			shape, err := shp.ParseWKT(wkt)
+			require.NoError(t, err)
+
+			again, err := shape.MarshalWKT()
+			require.NoError(t, err)
+			require.Equal(t, tt.wkt, string(again))
+		})
+	}
+}
+
+func TestParseWKT(t *testing.T) {
+	// Counterclockwise outer ring and clockwise hole, as in the OGC specification
+	shape, err := shp.ParseWKT([]byte("polygon((0 0,10 0,10 10,0 10,0 0),(2 2,2 4,4 4,4 2,2 2))"))
+	require.NoError(t, err)
+
+	polygon, ok := shape.(shp.Polygon)
+	require.True(t, ok)
+	require.Len(t, polygon.Parts, 2)
+	require.True(t, polygon.Parts[0].IsClockwise())
+	require.False(t, polygon.Parts[1].IsClockwise())
+	require.Equal(t, shp.BoundingBox{MinX: 0, MinY: 0, MaxX: 10, MaxY: 10}, polygon.BoundingBox)
+
+	shape, err = shp.ParseWKT([]byte("LINESTRING (1 2, 3 4)"))
+	require.NoError(t, err)
+	require.Equal(t, shp.PolylineType, shape.Type())
+
+	for _, wkt := range []string{
+		"",
+		"POINT EMPTY",
+		"POINT (1)",
+		"POINT (1 2) POINT (3 4)",
+		"MULTIPOINT ((1 2))",
+		"POLYGON ((0 0, 1 0, 1 1, 0 0.5))",
+		"LINESTRING (1 2, 3 4",
+	} {
+		_, err := shp.ParseWKT([]byte(wkt))
+		require.Error(t, err, wkt)
+	}
+}
@@ -0,0 +1,165 @@
+package shp
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Writer encodes shapes to a shp file and its shx index.
+// All shapes must be of the same type, and are numbered in the order that they're written.
+type Writer struct {
+	shp, shx io.WriteSeeker
+	typ      ShapeType
+
+	box    *BoundingBox
+	num    uint32
+	length uint32 // length of the shp file in bytes
+}
+
+// NewWriter creates a Writer for shapes of the specified type.
+// Space is reserved for the file headers, which are written by Close.
+func NewWriter(shp, shx io.WriteSeeker, typ ShapeType) (*Writer, error) {
+	switch typ {
+	case PointType, PolylineType, PolygonType:
+	default:
+		return nil, fmt.Errorf("unsupported shape type %s", typ)
+	}
+
+	w := &Writer{
+		shp:    shp,
+		shx:    shx,
+		typ:    typ,
+		length: 100,
+	}
+
+	for _, out := range []io.Writer{shp, shx} {
+		if _, err := out.Write(make([]byte, 100)); err != nil {
+			return nil, fmt.Errorf("failed to write header: %w", err)
+		}
+	}
+	return w, nil
+}
+
+// Write encodes a single shape, along with its index record.
+func (w *Writer) Write(shape Shape) error {
+	if shape.Type() != w.typ {
+		return fmt.Errorf("expecting shape type %s but have %s", w.typ, shape.Type())
+	}
+
+	box := boxFromPoints(shape.points())
+	if w.box == nil {
+		w.box = &box
+	} else {
+		w.box = &BoundingBox{
+			MinX: math.Min(w.box.MinX, box.MinX),
+			MinY: math.Min(w.box.MinY, box.MinY),
+			MaxX: math.Max(w.box.MaxX, box.MaxX),
+			MaxY: math.Max(w.box.MaxY, box.MaxY),
+		}
+	}
+
+	var content []byte
+	content = appendUint32(content, uint32(w.typ))
+	switch s := shape.(type) {
+	case Point:
+		content = appendPoint(content, s)
+	case Polyline:
+		content = appendParts(content, box, s.Parts)
+	case Polygon:
+		content = appendParts(content, box, s.Parts)
+	}
+
+	w.num++
+	buf := make([]byte, 8, 8+len(content))
+	binary.BigEndian.PutUint32(buf[0:4], w.num)
+	binary.BigEndian.PutUint32(buf[4:8], uint32(len(content)/2))
+	if _, err := w.shp.Write(append(buf, content...)); err != nil {
+		return fmt.Errorf("failed to write shape %d: %w", w.num, err)
+	}
+
+	// Offset and length are in 16-bit words
+	index := make([]byte, 8)
+	binary.BigEndian.PutUint32(index[0:4], w.length/2)
+	binary.BigEndian.PutUint32(index[4:8], uint32(len(content)/2))
+	if _, err := w.shx.Write(index); err != nil {
+		return fmt.Errorf("failed to write index %d: %w", w.num, err)
+	}
+
+	w.length += uint32(8 + len(content))
+	return nil
+}
+
+// Close writes the file headers. It does not close the underlying writers.
+func (w *Writer) Close() error {
+	var box BoundingBox
+	if w.box != nil {
+		box = *w.box
+	}
+
+	for _, f := range []struct {
+		out    io.WriteSeeker
+		length uint32
+	}{
+		{w.shp, w.length},
+		{w.shx, 100 + w.num*8},
+	} {
+		if _, err := f.out.Seek(0, io.SeekStart); err != nil {
+			return fmt.Errorf("failed to write header: %w", err)
+		}
+
+		if _, err := f.out.Write(encodeHeader(Header{
+			FileLength:  f.length,
+			Version:     1000,
+			ShapeType:   w.typ,
+			BoundingBox: box,
+		})); err != nil {
+			return fmt.Errorf("failed to write header: %w", err)
+		}
+
+		if _, err := f.out.Seek(0, io.SeekEnd); err != nil {
+			return fmt.Errorf("failed to write header: %w", err)
+		}
+	}
+	return nil
+}
+
+// encodeHeader encodes a shp or shx header, where the Z and M ranges are always zero.
+func encodeHeader(h Header) []byte {
+	buf := make([]byte, 100)
+	binary.BigEndian.PutUint32(buf[0:4], 0x0000270a)
+	binary.BigEndian.PutUint32(buf[24:28], h.FileLength/2)
+	binary.LittleEndian.PutUint32(buf[28:32], h.Version)
+	binary.LittleEndian.PutUint32(buf[32:36], uint32(h.ShapeType))
+	binary.LittleEndian.PutUint64(buf[36:44], math.Float64bits(h.BoundingBox.MinX))
+	binary.LittleEndian.PutUint64(buf[44:52], math.Float64bits(h.BoundingBox.MinY))
+	binary.LittleEndian.PutUint64(buf[52:60], math.Float64bits(h.BoundingBox.MaxX))
+	binary.LittleEndian.PutUint64(buf[60:68], math.Float64bits(h.BoundingBox.MaxY))
+	return buf
+}
+
+func appendParts(buf []byte, box BoundingBox, parts []Part) []byte {
+	buf = appendPoint(buf, MakePoint(box.MinX, box.MinY))
+	buf = appendPoint(buf, MakePoint(box.MaxX, box.MaxY))
+
+	var numPoints uint32
+	for _, part := range parts {
+		numPoints += uint32(len(part))
+	}
+	buf = appendUint32(buf, uint32(len(parts)))
+	buf = appendUint32(buf, numPoints)
+
+	var index uint32
+	for _, part := range parts {
+		buf = appendUint32(buf, index)
+		index += uint32(len(part))
+	}
+
+	for _, part := range parts {
+		for _, p := range part {
+			buf = appendPoint(buf, p)
+		}
+	}
+	return buf
+}
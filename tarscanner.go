@@ -0,0 +1,183 @@
+package shapefile
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"strings"
+	"sync"
+)
+
+// TarScanner wraps Scanner, providing a simple method of reading a shapefile from a tar file,
+// which may optionally be compressed with gzip.
+type TarScanner struct {
+	opts []Option
+
+	in   tarArchive
+	name string
+
+	initOnce sync.Once
+	scanner  *Scanner
+}
+
+// NewTarScanner creates a TarScanner for the supplied tar file.
+// The filename parameter should be the tar file's name (as stored on disk), and MUST match the names
+// of the contained shp and dbf files. Names ending in .tar.gz or .tgz are decompressed with gzip.
+// As tar files don't support random access, the contents of the file are read into memory.
+func NewTarScanner(r io.Reader, filename string, opts ...Option) (*TarScanner, error) {
+	var name string
+	var compressed bool
+	switch lower := strings.ToLower(filename); {
+	case strings.HasSuffix(lower, ".tar"):
+		name = filename[:len(filename)-len(".tar")]
+	case strings.HasSuffix(lower, ".tar.gz"):
+		name = filename[:len(filename)-len(".tar.gz")]
+		compressed = true
+	case strings.HasSuffix(lower, ".tgz"):
+		name = filename[:len(filename)-len(".tgz")]
+		compressed = true
+	default:
+		return nil, fmt.Errorf("expecting name to be *.tar, *.tar.gz or *.tgz")
+	}
+
+	if compressed {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress: %w", err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	in, err := readTar(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TarScanner{
+		opts: opts,
+		in:   in,
+		name: name,
+	}, nil
+}
+
+// Layers lists every shapefile layer inside the tar file.
+func (s *TarScanner) Layers() []Layer {
+	return findLayers(s.in.names())
+}
+
+// AddOptions allows additional options to be set after the scanner has already been created.
+func (s *TarScanner) AddOptions(opts ...Option) {
+	s.opts = append(s.opts, opts...)
+	if s.scanner != nil {
+		s.scanner.AddOptions(s.opts...)
+	}
+}
+
+// Info calls Scanner.Info().
+func (s *TarScanner) Info() (*Info, error) {
+	if err := s.init(); err != nil {
+		return nil, err
+	}
+	return s.scanner.Info()
+}
+
+// Scan calls Scanner.Scan().
+func (s *TarScanner) Scan() error {
+	if err := s.init(); err != nil {
+		return err
+	}
+	return s.scanner.Scan()
+}
+
+// Record calls Scanner.Record().
+func (s *TarScanner) Record() *Record {
+	if s.scanner == nil {
+		return nil
+	}
+	return s.scanner.Record()
+}
+
+// Err returns the first error encountered when parsing records.
+// It should be called after calling the Record method for the last time.
+func (s *TarScanner) Err() error {
+	if s.scanner == nil {
+		return nil
+	}
+	return s.scanner.Err()
+}
+
+func (s *TarScanner) init() error {
+	var err error
+
+	s.initOnce.Do(func() {
+		var layer Layer
+		if layer, err = selectLayer(s.Layers(), s.name); err != nil {
+			return
+		}
+		s.scanner, _, err = openLayer(s.in, layer, s.opts)
+	})
+
+	return err
+}
+
+// tarArchive holds the members of a tar file that belong to layers in memory.
+type tarArchive struct {
+	files []string
+	data  map[string][]byte
+}
+
+func readTar(r io.Reader) (tarArchive, error) {
+	a := tarArchive{
+		data: make(map[string][]byte),
+	}
+
+	in := tar.NewReader(r)
+	for {
+		h, err := in.Next()
+		if err == io.EOF {
+			return a, nil
+		} else if err != nil {
+			return tarArchive{}, fmt.Errorf("failed to read tar file: %w", err)
+		}
+
+		if h.Typeflag != tar.TypeReg {
+			continue
+		} else if _, ok := layerExts[strings.ToLower(path.Ext(h.Name))]; !ok {
+			continue
+		}
+
+		buf, err := ioutil.ReadAll(in)
+		if err != nil {
+			return tarArchive{}, fmt.Errorf("failed to read %s: %w", h.Name, err)
+		}
+
+		a.files = append(a.files, h.Name)
+		a.data[h.Name] = buf
+	}
+}
+
+func (a tarArchive) names() []string {
+	return a.files
+}
+
+func (a tarArchive) open(name string) (io.ReadCloser, error) {
+	buf, ok := a.data[name]
+	if !ok {
+		return nil, fmt.Errorf("missing file %s", name)
+	}
+	return tarMember{bytes.NewReader(buf)}, nil
+}
+
+// tarMember is a member of a tar file held in memory, which supports random access.
+type tarMember struct {
+	*bytes.Reader
+}
+
+func (tarMember) Close() error {
+	return nil
+}
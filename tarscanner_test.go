@@ -0,0 +1,113 @@
+package shapefile_test
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/everystreet/go-shapefile"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveScanner(t *testing.T) {
+	shp, err := os.ReadFile(filepath.Join("testdata", "ne_110m_admin_0_sovereignty.shp"))
+	require.NoError(t, err)
+
+	dbf, err := os.ReadFile(filepath.Join("testdata", "ne_110m_admin_0_sovereignty.dbf"))
+	require.NoError(t, err)
+
+	tarball := func(compress bool) []byte {
+		buf := &bytes.Buffer{}
+		var w io.Writer = buf
+
+		var gz *gzip.Writer
+		if compress {
+			gz = gzip.NewWriter(buf)
+			w = gz
+		}
+
+		tw := tar.NewWriter(w)
+		for _, f := range []struct {
+			name string
+			data []byte
+		}{
+			{"countries/", nil},
+			{"countries/countries.shp", shp},
+			{"countries/countries.dbf", dbf},
+			{"countries/countries.cpg", []byte("UTF-8")},
+		} {
+			h := &tar.Header{Name: f.name, Mode: 0644, Size: int64(len(f.data))}
+			if f.data == nil {
+				h.Typeflag = tar.TypeDir
+			}
+			require.NoError(t, tw.WriteHeader(h))
+			_, err := tw.Write(f.data)
+			require.NoError(t, err)
+		}
+		require.NoError(t, tw.Close())
+
+		if compress {
+			require.NoError(t, gz.Close())
+		}
+		return buf.Bytes()
+	}
+
+	shz := func() []byte {
+		buf := &bytes.Buffer{}
+		w := zip.NewWriter(buf)
+		for name, data := range map[string][]byte{
+			"layer.shp": shp,
+			"layer.dbf": dbf,
+		} {
+			f, err := w.Create(name)
+			require.NoError(t, err)
+			_, err = f.Write(data)
+			require.NoError(t, err)
+		}
+		require.NoError(t, w.Close())
+		return buf.Bytes()
+	}
+
+	tests := []struct {
+		filename string
+		data     []byte
+	}{
+		{"countries.tar", tarball(false)},
+		{"countries.tar.gz", tarball(true)},
+		{"countries.tgz", tarball(true)},
+		{"sovereignty.shz", shz()},
+		{"sovereignty.shp.zip", shz()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filename, func(t *testing.T) {
+			r := bytes.NewReader(tt.data)
+			s, err := shapefile.NewArchiveScanner(r, r.Size(), tt.filename)
+			require.NoError(t, err)
+
+			info, err := s.Info()
+			require.NoError(t, err)
+
+			err = s.Scan()
+			require.NoError(t, err)
+
+			var num uint32
+			for s.Record() != nil {
+				num++
+			}
+
+			require.NoError(t, s.Err())
+			require.Equal(t, info.NumRecords, num)
+		})
+	}
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := shapefile.NewArchiveScanner(bytes.NewReader(nil), 0, "countries.rar")
+		require.EqualError(t, err, "unsupported archive format 'countries.rar'")
+	})
+}
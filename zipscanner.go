@@ -2,14 +2,10 @@ package shapefile
 
 import (
 	"archive/zip"
-	"bufio"
 	"fmt"
 	"io"
 	"strings"
 	"sync"
-
-	"golang.org/x/net/html/charset"
-	"golang.org/x/text/encoding"
 )
 
 // ZipScanner wraps Scanner, providing a simple method of reading a zipped shapefile.
@@ -26,23 +22,64 @@ type ZipScanner struct {
 // NewZipScanner creates a ZipScanner for the supplied zip file.
 // The filename parameter should be the zip file's name (as stored on disk),
 // and MUST match the names of the contained shp and dbf files.
+// Single-layer archives named *.shz or *.shp.zip are the exception,
+// as the names of the contained files are not required to match.
 func NewZipScanner(r io.ReaderAt, size int64, filename string, opts ...Option) (*ZipScanner, error) {
 	in, err := zip.NewReader(r, size)
 	if err != nil {
 		return nil, err
 	}
 
-	if !strings.HasSuffix(filename, ".zip") {
-		return nil, fmt.Errorf("expecting name to be *.zip")
+	var name string
+	switch lower := strings.ToLower(filename); {
+	case strings.HasSuffix(lower, ".shz"), strings.HasSuffix(lower, ".shp.zip"):
+	case strings.HasSuffix(lower, ".zip"):
+		name = filename[:len(filename)-len(".zip")]
+	default:
+		return nil, fmt.Errorf("expecting name to be *.zip, *.shz or *.shp.zip")
+	}
+
+	return &ZipScanner{
+		opts: opts,
+		in:   in,
+		name: name,
+	}, nil
+}
+
+// NewZipLayerScanner creates a ZipScanner for a single layer of a zip file that may contain several.
+// The layer parameter is either the full name of the layer, as returned by ZipLayers,
+// or its base name if that is unique within the zip file.
+func NewZipLayerScanner(r io.ReaderAt, size int64, layer string, opts ...Option) (*ZipScanner, error) {
+	in, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := selectLayer(zipArchive{in}.layers(), layer); err != nil {
+		return nil, err
 	}
 
 	return &ZipScanner{
 		opts: opts,
 		in:   in,
-		name: strings.TrimSuffix(filename, ".zip"),
+		name: layer,
 	}, nil
 }
 
+// ZipLayers lists every shapefile layer inside the supplied zip file, including those in subdirectories.
+func ZipLayers(r io.ReaderAt, size int64) ([]Layer, error) {
+	in, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	return zipArchive{in}.layers(), nil
+}
+
+// Layers lists every shapefile layer inside the zip file.
+func (s *ZipScanner) Layers() []Layer {
+	return zipArchive{s.in}.layers()
+}
+
 // AddOptions allows additional options to be set after the scanner has already been created.
 func (s *ZipScanner) AddOptions(opts ...Option) {
 	s.opts = append(s.opts, opts...)
@@ -88,106 +125,41 @@ func (s *ZipScanner) init() error {
 	var err error
 
 	s.initOnce.Do(func() {
-		var shpFile, dbfFile, cpgFile *zip.File
-		shpFile, dbfFile, cpgFile, err = s.files()
-		if err != nil {
-			return
-		}
-
-		var shpR, dbfR io.Reader
-		shpR, err = shpFile.Open()
-		if err != nil {
-			err = fmt.Errorf("failed to open %s: %w", shpFile.Name, err)
+		var layer Layer
+		if layer, err = selectLayer(zipArchive{s.in}.layers(), s.name); err != nil {
 			return
 		}
-
-		dbfR, err = dbfFile.Open()
-		if err != nil {
-			err = fmt.Errorf("failed to open %s: %w", dbfFile.Name, err)
-			return
-		}
-
-		opts := make([]Option, len(s.opts))
-		copy(opts, s.opts)
-
-		if cpgFile != nil {
-			var dec *encoding.Decoder
-			dec, err = readCpg(cpgFile)
-			if err != nil {
-				return
-			}
-			opts = append(opts, CharacterDecoder(dec))
-		}
-
-		s.scanner = NewScanner(shpR, dbfR, opts...)
+		// Closing zip members only releases decompressors, so there's no need to keep hold of them
+		s.scanner, _, err = openLayer(zipArchive{s.in}, layer, s.opts)
 	})
 
 	return err
 }
 
-func (s *ZipScanner) files() (shpFile, dbfFile, cpgFile *zip.File, err error) {
-	if s.name != "" {
-		for _, f := range s.in.File {
-			switch f.Name {
-			case s.name + ".shp":
-				shpFile = f
-			case s.name + ".dbf":
-				dbfFile = f
-			case s.name + ".cpg":
-				cpgFile = f
-			}
-		}
-	} else {
-		for _, f := range s.in.File {
-			switch {
-			case strings.HasSuffix(f.Name, ".shp"):
-				if shpFile != nil {
-					err = fmt.Errorf("found multiple .shp files")
-					return
-				}
-				shpFile = f
-			case strings.HasSuffix(f.Name, ".dbf"):
-				if dbfFile != nil {
-					err = fmt.Errorf("found multiple .dbf files")
-					return
-				}
-				dbfFile = f
-			case strings.HasSuffix(f.Name, "cpg"):
-				if cpgFile != nil {
-					err = fmt.Errorf("found multiple .cpg files")
-					return
-				}
-				cpgFile = f
-			}
-		}
-	}
-
-	if shpFile == nil {
-		err = fmt.Errorf("missing .shp file")
-	} else if dbfFile == nil {
-		err = fmt.Errorf("missing .dbf file")
-	}
-	return
+// zipArchive provides access to the members of a zip file.
+type zipArchive struct {
+	*zip.Reader
 }
 
-func readCpg(f *zip.File) (*encoding.Decoder, error) {
-	r, err := f.Open()
-	if err != nil {
-		return nil, fmt.Errorf("failed to open cpg file: %w", err)
+func (a zipArchive) names() []string {
+	names := make([]string, 0, len(a.File))
+	for _, f := range a.File {
+		if !f.FileInfo().IsDir() {
+			names = append(names, f.Name)
+		}
 	}
+	return names
+}
 
-	scanner := bufio.NewScanner(r)
-	for scanner.Scan() {
-		str := strings.TrimSpace(scanner.Text())
-		if len(str) == 0 {
-			continue
-		}
+func (a zipArchive) layers() []Layer {
+	return findLayers(a.names())
+}
 
-		enc, _ := charset.Lookup(str)
-		if enc == nil {
-			return nil, fmt.Errorf("unknown charset '%s'", str)
+func (a zipArchive) open(name string) (io.ReadCloser, error) {
+	for _, f := range a.File {
+		if f.Name == name {
+			return f.Open()
 		}
-		return enc.NewDecoder(), nil
 	}
-	return nil, fmt.Errorf("missing charset")
+	return nil, fmt.Errorf("missing file %s", name)
 }
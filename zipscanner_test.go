@@ -1,6 +1,9 @@
 package shapefile_test
 
 import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"testing"
@@ -38,5 +41,80 @@ func TestScanZip(t *testing.T) {
 	require.NoError(t, s.Err())
 	require.Equal(t, info.NumRecords, num)
 
+	// The extension is matched regardless of case
+	_, err = shapefile.NewZipScanner(r, stat.Size(), "ne_110m_admin_0_sovereignty.ZIP")
+	require.NoError(t, err)
+
 	require.NoError(t, r.Close())
 }
+
+func TestZipLayers(t *testing.T) {
+	shp, err := ioutil.ReadFile(filepath.Join("testdata", "ne_110m_admin_0_sovereignty.shp"))
+	require.NoError(t, err)
+
+	dbf, err := ioutil.ReadFile(filepath.Join("testdata", "ne_110m_admin_0_sovereignty.dbf"))
+	require.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	w := zip.NewWriter(buf)
+	for name, data := range map[string][]byte{
+		"countries/sovereignty.SHP":   shp,
+		"countries/sovereignty.Dbf":   dbf,
+		"countries/sovereignty.cpg":   []byte("UTF-8"),
+		"other/sovereignty.shp":       shp,
+		"other/sovereignty.dbf":       dbf,
+		"other/readme.txt":            []byte("not a layer"),
+		"other/orphan.dbf":            dbf,
+		"copy_of_sovereignty.shp":     shp,
+		"copy_of_sovereignty.DBF":     dbf,
+		"copy_of_sovereignty.shp.xml": []byte("<metadata/>"),
+	} {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write(data)
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+
+	r := bytes.NewReader(buf.Bytes())
+
+	layers, err := shapefile.ZipLayers(r, r.Size())
+	require.NoError(t, err)
+	require.Len(t, layers, 3)
+
+	require.Equal(t, "copy_of_sovereignty", layers[0].Name)
+	require.Equal(t, "countries/sovereignty", layers[1].Name)
+	require.Equal(t, "other/sovereignty", layers[2].Name)
+
+	name, ok := layers[1].File(".dbf")
+	require.True(t, ok)
+	require.Equal(t, "countries/sovereignty.Dbf", name)
+
+	_, ok = layers[2].File(".cpg")
+	require.False(t, ok)
+
+	t.Run("ambiguous base name", func(t *testing.T) {
+		_, err := shapefile.NewZipLayerScanner(r, r.Size(), "sovereignty")
+		require.Error(t, err)
+	})
+
+	t.Run("scan layer", func(t *testing.T) {
+		s, err := shapefile.NewZipLayerScanner(r, r.Size(), "countries/sovereignty")
+		require.NoError(t, err)
+
+		info, err := s.Info()
+		require.NoError(t, err)
+		require.Equal(t, "utf-8", info.Encoding)
+
+		err = s.Scan()
+		require.NoError(t, err)
+
+		var num uint32
+		for s.Record() != nil {
+			num++
+		}
+
+		require.NoError(t, s.Err())
+		require.Equal(t, info.NumRecords, num)
+	})
+}